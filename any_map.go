@@ -0,0 +1,247 @@
+package ordered
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/buger/jsonparser"
+)
+
+// Hasher hashes and compares the keys of an AnyMap. It allows the keys
+// whose type is not comparable e.g. slices to be used in an AnyMap. Equal
+// keys must have the same hash.
+type Hasher interface {
+	Hash(key any) uint64
+	Equal(a, b any) bool
+}
+
+// AnyKeyValue represents an AnyMap element as a key-value pair.
+type AnyKeyValue struct {
+	Key   any
+	Value any
+}
+
+// AnyMap is a non-generic ordered map whose key and value types are
+// provided as reflect.Type at runtime. It has the same ordering semantics
+// as Map and is meant for the cases where the types are only known at
+// runtime e.g. plugin systems and script bindings. Putting a key or a
+// value which is not assignable to the corresponding type panics.
+type AnyMap struct {
+	keyType   reflect.Type
+	valueType reflect.Type
+	hm        *hashMap[any, any]
+}
+
+// NewAnyMap initializes an ordered map with the given key and value types.
+// It panics if the key type is not comparable.
+func NewAnyMap(keyType, valueType reflect.Type) *AnyMap {
+	if !keyType.Comparable() {
+		panic(fmt.Sprintf("ordered: key type %s is not comparable", keyType))
+	}
+	return &AnyMap{
+		keyType:   keyType,
+		valueType: valueType,
+		hm: newHashMap[any, any](
+			func(k any) any { return k },
+			func(a, b any) bool { return a == b },
+		),
+	}
+}
+
+// NewAnyMapWithHasher initializes an ordered map with the given key and value
+// types. The keys are hashed and compared by the given hasher, so the key
+// type does not need to be comparable.
+func NewAnyMapWithHasher(keyType, valueType reflect.Type, hasher Hasher) *AnyMap {
+	return &AnyMap{
+		keyType:   keyType,
+		valueType: valueType,
+		hm: newHashMap[any, any](
+			func(k any) any { return hasher.Hash(k) },
+			hasher.Equal,
+		),
+	}
+}
+
+// KeyType returns the key type of the map.
+func (o *AnyMap) KeyType() reflect.Type {
+	return o.keyType
+}
+
+// ValueType returns the value type of the map.
+func (o *AnyMap) ValueType() reflect.Type {
+	return o.valueType
+}
+
+// Put inserts a key and its mapped value in the map. If the key already exists, the
+// mapped value is replaced by the new value.
+func (o *AnyMap) Put(key, value any) {
+	o.hm.put(assignTo(key, o.keyType, "key"), assignTo(value, o.valueType, "value"))
+}
+
+// Get returns the mapped value for the given key and a bool indicating
+// whether the key exists or not.
+func (o *AnyMap) Get(key any) (any, bool) {
+	return o.hm.get(assignTo(key, o.keyType, "key"))
+}
+
+// GetOrDefault returns the mapped value for the given key if it exists.
+// Otherwise, it returns the default value.
+func (o *AnyMap) GetOrDefault(key any, defaultValue any) any {
+	if val, ok := o.Get(key); ok {
+		return val
+	}
+	return defaultValue
+}
+
+// ContainsKey checks if the map contains a mapping for the given key.
+func (o *AnyMap) ContainsKey(key any) bool {
+	_, ok := o.Get(key)
+	return ok
+}
+
+// Remove removes the key with its mapped value from the map and returns
+// the value if the key exists.
+func (o *AnyMap) Remove(key any) any {
+	value, _ := o.hm.remove(assignTo(key, o.keyType, "key"))
+	return value
+}
+
+// Len returns the number of elements in the map.
+func (o *AnyMap) Len() int {
+	return o.hm.len()
+}
+
+// Keys returns all the keys from the map according to their insertion order.
+// The first element of the slice is the oldest key in the map.
+func (o *AnyMap) Keys() []any {
+	keys := make([]any, 0, o.hm.len())
+	o.hm.forEach(func(k, _ any) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+// Values returns all the values from the map according to their insertion order.
+// The first element of the slice is the oldest value in the map.
+func (o *AnyMap) Values() []any {
+	values := make([]any, 0, o.hm.len())
+	o.hm.forEach(func(_, v any) {
+		values = append(values, v)
+	})
+	return values
+}
+
+// KeyValues returns all the keys and values from the map according to their
+// insertion order. The first element of the slice is the oldest key and value
+// in the map.
+func (o *AnyMap) KeyValues() []AnyKeyValue {
+	kvs := make([]AnyKeyValue, 0, o.hm.len())
+	o.hm.forEach(func(k, v any) {
+		kvs = append(kvs, AnyKeyValue{Key: k, Value: v})
+	})
+	return kvs
+}
+
+// ForEach invokes the given function f for each element of the map.
+func (o *AnyMap) ForEach(f func(any, any)) {
+	for _, kv := range o.KeyValues() {
+		f(kv.Key, kv.Value)
+	}
+}
+
+// IsEmpty checks whether the map is empty or not.
+func (o *AnyMap) IsEmpty() bool {
+	return o.hm.len() == 0
+}
+
+// Clear removes all the keys and their mapped values from the map.
+func (o *AnyMap) Clear() {
+	o.hm.clear()
+}
+
+// String returns the string representation of the map.
+func (o *AnyMap) String() string {
+	var sb strings.Builder
+	sb.WriteString("map{")
+	for idx, kv := range o.KeyValues() {
+		if idx > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(fmt.Sprint(kv.Key))
+		sb.WriteByte(':')
+		sb.WriteString(fmt.Sprint(kv.Value))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (o AnyMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for idx, kv := range o.KeyValues() {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+		keyBytes, err := marshalKey(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyBytes)
+
+		buf.WriteByte(':')
+		valBytes, err := json.Marshal(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(valBytes)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. The map must be
+// initialized by NewAnyMap or NewAnyMapWithHasher so that the key and
+// value types are known.
+func (o *AnyMap) UnmarshalJSON(b []byte) error {
+	if o.hm == nil {
+		return errors.New("uninitialized map")
+	}
+	zeroKey := reflect.Zero(o.keyType).Interface()
+	return jsonparser.ObjectEach(b, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		k := reflect.New(o.keyType)
+		if err := unmarshalKey(key, zeroKey, k.Interface()); err != nil {
+			return err
+		}
+		v := reflect.New(o.valueType)
+		if err := json.Unmarshal(rawValue(value, dataType), v.Interface()); err != nil {
+			return err
+		}
+		o.hm.put(k.Elem().Interface(), v.Elem().Interface())
+		return nil
+	})
+}
+
+// assignTo returns v as a value of type t. It panics if v is not assignable
+// to t.
+func assignTo(v any, t reflect.Type, what string) any {
+	if v == nil {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+			return reflect.Zero(t).Interface()
+		}
+		panic(fmt.Sprintf("ordered: nil %s is not assignable to %s", what, t))
+	}
+	rv := reflect.ValueOf(v)
+	if !rv.Type().AssignableTo(t) {
+		panic(fmt.Sprintf("ordered: %s of type %s is not assignable to %s", what, rv.Type(), t))
+	}
+	if t.Kind() == reflect.Interface || rv.Type() == t {
+		return v
+	}
+	return rv.Convert(t).Interface()
+}
@@ -0,0 +1,195 @@
+package ordered_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceHasher hashes []int keys by their content.
+type sliceHasher struct{}
+
+func (sliceHasher) Hash(key any) uint64 {
+	h := fnv.New64a()
+	fmt.Fprint(h, key)
+	return h.Sum64()
+}
+
+func (sliceHasher) Equal(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// collidingHasher puts every key in the same bucket.
+type collidingHasher struct{ sliceHasher }
+
+func (collidingHasher) Hash(key any) uint64 {
+	return 0
+}
+
+var (
+	stringType = reflect.TypeOf("")
+	intType    = reflect.TypeOf(0)
+	sliceType  = reflect.TypeOf([]int(nil))
+)
+
+func TestNewAnyMap(t *testing.T) {
+	om := ordered.NewAnyMap(stringType, intType)
+
+	assert.True(t, om.IsEmpty())
+	assert.Equal(t, stringType, om.KeyType())
+	assert.Equal(t, intType, om.ValueType())
+
+	assert.Panics(t, func() {
+		ordered.NewAnyMap(sliceType, intType)
+	})
+}
+
+func TestAnyMapPutGet(t *testing.T) {
+	om := ordered.NewAnyMap(stringType, intType)
+	om.Put("foo", 1)
+	om.Put("bar", 2)
+	om.Put("foo", 3)
+
+	val, ok := om.Get("foo")
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+
+	_, ok = om.Get("baz")
+	assert.False(t, ok)
+	assert.Equal(t, 10, om.GetOrDefault("baz", 10))
+	assert.True(t, om.ContainsKey("bar"))
+	assert.Equal(t, 2, om.Len())
+	assert.Equal(t, []any{"foo", "bar"}, om.Keys())
+	assert.Equal(t, []any{3, 2}, om.Values())
+
+	t.Run("type mismatch", func(t *testing.T) {
+		assert.Panics(t, func() { om.Put(1, 1) })
+		assert.Panics(t, func() { om.Put("foo", "bar") })
+		assert.Panics(t, func() { om.Put(nil, 1) })
+	})
+
+	t.Run("assignable types", func(t *testing.T) {
+		type ints []int
+		om := ordered.NewAnyMap(stringType, sliceType)
+		om.Put("foo", ints{1, 2})
+		om.Put("bar", nil)
+
+		assert.Equal(t, []any{[]int{1, 2}, []int(nil)}, om.Values())
+	})
+}
+
+func TestAnyMapRemove(t *testing.T) {
+	om := ordered.NewAnyMap(stringType, intType)
+	om.Put("foo", 1)
+	om.Put("bar", 2)
+	om.Put("baz", 3)
+
+	assert.Equal(t, 2, om.Remove("bar"))
+	assert.Nil(t, om.Remove("bar"))
+	assert.Equal(t, []any{"foo", "baz"}, om.Keys())
+
+	om.Clear()
+	assert.True(t, om.IsEmpty())
+	assert.Equal(t, []any{}, om.Keys())
+}
+
+func TestAnyMapWithHasher(t *testing.T) {
+	for _, hasher := range []ordered.Hasher{sliceHasher{}, collidingHasher{}} {
+		om := ordered.NewAnyMapWithHasher(sliceType, stringType, hasher)
+		om.Put([]int{1, 2}, "a")
+		om.Put([]int{3}, "b")
+		om.Put([]int{4, 5, 6}, "c")
+		om.Put([]int{1, 2}, "d")
+
+		assert.Equal(t, 3, om.Len())
+		assert.Equal(t, []ordered.AnyKeyValue{
+			{Key: []int{1, 2}, Value: "d"},
+			{Key: []int{3}, Value: "b"},
+			{Key: []int{4, 5, 6}, Value: "c"},
+		}, om.KeyValues())
+
+		assert.Equal(t, "b", om.Remove([]int{3}))
+		assert.False(t, om.ContainsKey([]int{3}))
+		assert.True(t, om.ContainsKey([]int{4, 5, 6}))
+		assert.Equal(t, "map{[1 2]:d [4 5 6]:c}", om.String())
+	}
+}
+
+func TestAnyMapForEach(t *testing.T) {
+	om := ordered.NewAnyMap(intType, stringType)
+	om.Put(2, "two")
+	om.Put(1, "one")
+
+	var keys, vals []any
+	om.ForEach(func(k, v any) {
+		keys = append(keys, k)
+		vals = append(vals, v)
+	})
+	assert.Equal(t, []any{2, 1}, keys)
+	assert.Equal(t, []any{"two", "one"}, vals)
+}
+
+func TestAnyMapMarshalJSON(t *testing.T) {
+	t.Run("int struct map", func(t *testing.T) {
+		type dummy struct{ Elem string }
+		om := ordered.NewAnyMap(intType, reflect.TypeOf(dummy{}))
+		om.Put(3, dummy{"foo"})
+		om.Put(1, dummy{"bar"})
+
+		b, err := json.Marshal(om)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"3":{"Elem":"foo"},"1":{"Elem":"bar"}}`, string(b))
+	})
+
+	t.Run("invalid key type", func(t *testing.T) {
+		om := ordered.NewAnyMapWithHasher(sliceType, intType, sliceHasher{})
+		om.Put([]int{1}, 1)
+
+		_, err := json.Marshal(om)
+		assert.Error(t, err)
+	})
+}
+
+func TestAnyMapUnmarshalJSON(t *testing.T) {
+	t.Run("string slice map", func(t *testing.T) {
+		om := ordered.NewAnyMap(stringType, sliceType)
+		err := json.Unmarshal([]byte(`{"b":[1,2],"a":[3]}`), om)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"b", "a"}, om.Keys())
+		assert.Equal(t, []any{[]int{1, 2}, []int{3}}, om.Values())
+	})
+
+	t.Run("text unmarshaler key", func(t *testing.T) {
+		om := ordered.NewAnyMap(reflect.TypeOf(point3d{}), stringType)
+		err := json.Unmarshal([]byte(`{"1-2-3":"p1","4-5-6":"p2"}`), om)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []any{point3d{1, 2, 3}, point3d{4, 5, 6}}, om.Keys())
+	})
+
+	t.Run("interface value", func(t *testing.T) {
+		om := ordered.NewAnyMap(stringType, reflect.TypeOf((*any)(nil)).Elem())
+		err := json.Unmarshal([]byte(`{"a":"x","b":1}`), om)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"x", float64(1)}, om.Values())
+	})
+
+	t.Run("value unmarshalling error", func(t *testing.T) {
+		om := ordered.NewAnyMap(stringType, intType)
+		err := json.Unmarshal([]byte(`{"a":"x"}`), om)
+		assert.Error(t, err)
+	})
+
+	t.Run("uninitialized map", func(t *testing.T) {
+		var om ordered.AnyMap
+		err := json.Unmarshal([]byte(`{"a":1}`), &om)
+		assert.Error(t, err)
+	})
+}
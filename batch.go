@@ -0,0 +1,121 @@
+package ordered
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateKey is returned by Batch.Apply if more than one operation is
+// staged for the same key.
+var ErrDuplicateKey = errors.New("duplicate key in batch")
+
+type batchOp[K comparable, V any] struct {
+	key    K
+	value  V
+	remove bool
+}
+
+// Batch stages Put and Remove operations on a map and applies them all at
+// once. The staged operations are validated before the map is modified, so
+// either all of them are applied or none of them.
+type Batch[K comparable, V any] struct {
+	om     *Map[K, V]
+	target batchTarget[K, V]
+	ops    []batchOp[K, V]
+}
+
+// batchTarget applies the validated operations of a batch.
+type batchTarget[K comparable, V any] interface {
+	applyBatch(ops []batchOp[K, V])
+}
+
+// Batch returns a new empty batch of operations on the map.
+func (o *Map[K, V]) Batch() *Batch[K, V] {
+	return &Batch[K, V]{om: o, target: o}
+}
+
+// Batch returns a new empty batch of operations on the map. The applied
+// batch is recorded as a single version.
+func (o *VersionedMap[K, V]) Batch() *Batch[K, V] {
+	return &Batch[K, V]{om: o.Map, target: o}
+}
+
+func (o *Map[K, V]) applyBatch(ops []batchOp[K, V]) {
+	for _, op := range ops {
+		if op.remove {
+			o.Remove(op.key)
+		} else {
+			o.Put(op.key, op.value)
+		}
+	}
+}
+
+func (o *VersionedMap[K, V]) applyBatch(ops []batchOp[K, V]) {
+	o.group(func() {
+		for _, op := range ops {
+			if op.remove {
+				o.Remove(op.key)
+			} else {
+				o.Put(op.key, op.value)
+			}
+		}
+	})
+}
+
+// Put stages the insertion of a key and its mapped value.
+func (b *Batch[K, V]) Put(key K, value V) {
+	b.ops = append(b.ops, batchOp[K, V]{key: key, value: value})
+}
+
+// Remove stages the removal of a key.
+func (b *Batch[K, V]) Remove(key K) {
+	b.ops = append(b.ops, batchOp[K, V]{key: key, remove: true})
+}
+
+// Len returns the number of staged operations.
+func (b *Batch[K, V]) Len() int {
+	return len(b.ops)
+}
+
+// Discard drops all the staged operations.
+func (b *Batch[K, V]) Discard() {
+	b.ops = nil
+}
+
+// Apply validates the staged operations and applies them to the map in the
+// order they are staged. The validation fails with ErrDuplicateKey if a key
+// is staged more than once and with ErrFull if the operations would exceed
+// the maximum number of entries of the map. If the validation fails, the
+// map is not modified and the operations stay staged. The batch is empty
+// after a successful apply and can be reused.
+func (b *Batch[K, V]) Apply() error {
+	if err := b.validate(); err != nil {
+		return err
+	}
+	b.target.applyBatch(b.ops)
+	b.ops = nil
+	return nil
+}
+
+func (b *Batch[K, V]) validate() error {
+	seen := make(map[K]struct{}, len(b.ops))
+	size := b.om.Len()
+	for _, op := range b.ops {
+		if _, ok := seen[op.key]; ok {
+			return fmt.Errorf("%w: %v", ErrDuplicateKey, op.key)
+		}
+		seen[op.key] = struct{}{}
+
+		_, exists := b.om.lookup(op.key)
+		switch {
+		case op.remove && exists:
+			size--
+		case !op.remove && !exists:
+			size++
+			if limit := b.om.cfg.maxEntries; limit > 0 && size > limit {
+				return ErrFull
+			}
+		}
+	}
+	return nil
+}
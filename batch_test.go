@@ -0,0 +1,91 @@
+package ordered_test
+
+import (
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchApply(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs[string, int](kv{"a", 1}, kv{"b", 2})
+
+	b := om.Batch()
+	b.Put("c", 3)
+	b.Remove("a")
+	b.Put("b", 20)
+	assert.Equal(t, 3, b.Len())
+
+	// nothing is applied before Apply
+	assert.Equal(t, []kv{{"a", 1}, {"b", 2}}, om.KeyValues())
+
+	assert.NoError(t, b.Apply())
+	assert.Equal(t, []kv{{"b", 20}, {"c", 3}}, om.KeyValues())
+	assert.Equal(t, 0, b.Len())
+
+	// the batch is reusable
+	b.Put("d", 4)
+	assert.NoError(t, b.Apply())
+	assert.Equal(t, []string{"b", "c", "d"}, om.Keys())
+}
+
+func TestBatchDuplicateKey(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs[string, int](kv{"a", 1})
+
+	b := om.Batch()
+	b.Put("b", 2)
+	b.Put("c", 3)
+	b.Remove("b")
+
+	err := b.Apply()
+	assert.ErrorIs(t, err, ordered.ErrDuplicateKey)
+	assert.Equal(t, []kv{{"a", 1}}, om.KeyValues())
+	assert.Equal(t, 3, b.Len())
+
+	b.Discard()
+	assert.Equal(t, 0, b.Len())
+	assert.NoError(t, b.Apply())
+	assert.Equal(t, []kv{{"a", 1}}, om.KeyValues())
+}
+
+func TestBatchMaxEntries(t *testing.T) {
+	om := ordered.NewMap[string, int](ordered.WithMaxEntries(2, ordered.PolicyReject))
+	om.Put("a", 1)
+
+	b := om.Batch()
+	b.Put("b", 2)
+	b.Put("c", 3)
+	assert.ErrorIs(t, b.Apply(), ordered.ErrFull)
+	assert.Equal(t, []string{"a"}, om.Keys())
+
+	// a removal staged earlier makes room for a later insertion
+	b.Discard()
+	b.Remove("a")
+	b.Put("b", 2)
+	b.Put("c", 3)
+	assert.NoError(t, b.Apply())
+	assert.Equal(t, []string{"b", "c"}, om.Keys())
+}
+
+func TestVersionedMapBatch(t *testing.T) {
+	vm := ordered.NewVersionedMap[string, int]()
+	vm.Put("a", 1)
+	vm.Put("b", 2)
+
+	b := vm.Batch()
+	b.Remove("a")
+	b.Put("c", 3)
+	b.Put("b", 20)
+	assert.NoError(t, b.Apply())
+	assert.Equal(t, []string{"b", "c"}, vm.Keys())
+
+	assert.True(t, vm.Undo())
+	assert.Equal(t, []string{"a", "b"}, vm.Keys())
+	assert.Equal(t, []int{1, 2}, vm.Values())
+	assert.True(t, vm.CanUndo())
+
+	assert.True(t, vm.Redo())
+	assert.Equal(t, []int{20, 3}, vm.Values())
+}
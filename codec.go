@@ -0,0 +1,77 @@
+package ordered
+
+import "encoding/gob"
+
+// Codec converts values of type T to and from bytes. It is used by the
+// binary encoding of the maps and the sets for the types which do not
+// implement the encoding interfaces of the standard library e.g. the types
+// of third-party packages.
+type Codec[T any] interface {
+	Encode(T) ([]byte, error)
+	Decode([]byte) (T, error)
+}
+
+type funcCodec[T any] struct {
+	encode func(T) ([]byte, error)
+	decode func([]byte) (T, error)
+}
+
+func (c funcCodec[T]) Encode(v T) ([]byte, error) { return c.encode(v) }
+
+func (c funcCodec[T]) Decode(b []byte) (T, error) { return c.decode(b) }
+
+// NewCodec returns a Codec which uses the given functions.
+func NewCodec[T any](encode func(T) ([]byte, error), decode func([]byte) (T, error)) Codec[T] {
+	return funcCodec[T]{encode: encode, decode: decode}
+}
+
+// SetKeyCodec sets the codec used for the keys by GobEncode and GobDecode.
+// A nil codec restores the default gob encoding of the keys.
+func (o *Map[K, V]) SetKeyCodec(c Codec[K]) {
+	o.keyCodec = c
+}
+
+// SetValueCodec sets the codec used for the values by GobEncode and
+// GobDecode. A nil codec restores the default gob encoding of the values.
+func (o *Map[K, V]) SetValueCodec(c Codec[V]) {
+	o.valueCodec = c
+}
+
+// SetCodec sets the codec used for the elements by GobEncode and GobDecode.
+// A nil codec restores the default gob encoding of the elements.
+func (s *Set[T]) SetCodec(c Codec[T]) {
+	if s.mp == nil {
+		s.mp = NewMap[T, struct{}]()
+	}
+	s.mp.keyCodec = c
+}
+
+// encodeWith encodes v by gob either directly or as the bytes produced by
+// the codec if it is not nil.
+func encodeWith[T any](enc *gob.Encoder, c Codec[T], v T) error {
+	if c == nil {
+		return enc.Encode(v)
+	}
+	b, err := c.Encode(v)
+	if err != nil {
+		return err
+	}
+	return enc.Encode(b)
+}
+
+// decodeWith decodes a value encoded by encodeWith with the same codec.
+func decodeWith[T any](dec *gob.Decoder, c Codec[T], v *T) error {
+	if c == nil {
+		return dec.Decode(v)
+	}
+	var b []byte
+	if err := dec.Decode(&b); err != nil {
+		return err
+	}
+	decoded, err := c.Decode(b)
+	if err != nil {
+		return err
+	}
+	*v = decoded
+	return nil
+}
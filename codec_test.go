@@ -0,0 +1,96 @@
+package ordered_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+// coord has no exported fields, so gob cannot encode it by itself.
+type coord struct {
+	x, y int
+}
+
+var coordCodec = ordered.NewCodec(
+	func(p coord) ([]byte, error) {
+		return []byte(fmt.Sprintf("%d,%d", p.x, p.y)), nil
+	},
+	func(b []byte) (coord, error) {
+		var p coord
+		_, err := fmt.Sscanf(string(b), "%d,%d", &p.x, &p.y)
+		return p, err
+	},
+)
+
+func TestMapCodec(t *testing.T) {
+	om := ordered.NewMap[coord, coord]()
+	om.Put(coord{1, 2}, coord{3, 4})
+	om.Put(coord{0, 0}, coord{5, 6})
+
+	_, err := om.GobEncode()
+	assert.Error(t, err)
+
+	om.SetKeyCodec(coordCodec)
+	om.SetValueCodec(coordCodec)
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(om))
+
+	decoded := ordered.NewMap[coord, coord]()
+	decoded.SetKeyCodec(coordCodec)
+	decoded.SetValueCodec(coordCodec)
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	assert.Equal(t, om.KeyValues(), decoded.KeyValues())
+}
+
+func TestMapKeyCodecOnly(t *testing.T) {
+	om := ordered.NewMap[coord, string]()
+	om.SetKeyCodec(coordCodec)
+	om.Put(coord{1, 2}, "a")
+
+	b, err := om.GobEncode()
+	assert.NoError(t, err)
+
+	decoded := ordered.NewMap[coord, string]()
+	decoded.SetKeyCodec(coordCodec)
+	assert.NoError(t, decoded.GobDecode(b))
+	assert.Equal(t, "a", decoded.GetOrDefault(coord{1, 2}, ""))
+}
+
+func TestSetCodec(t *testing.T) {
+	s := ordered.NewSetWithElems(coord{2, 1}, coord{1, 2})
+	s.SetCodec(coordCodec)
+
+	b, err := s.GobEncode()
+	assert.NoError(t, err)
+
+	var decoded ordered.Set[coord]
+	decoded.SetCodec(coordCodec)
+	assert.NoError(t, decoded.GobDecode(b))
+	assert.Equal(t, []coord{{2, 1}, {1, 2}}, decoded.Elements())
+}
+
+func TestCodecError(t *testing.T) {
+	errCodec := errors.New("codec error")
+	failing := ordered.NewCodec(
+		func(p coord) ([]byte, error) { return nil, errCodec },
+		func(b []byte) (coord, error) { return coord{}, errCodec },
+	)
+
+	om := ordered.NewMap[coord, int]()
+	om.Put(coord{}, 1)
+	om.SetKeyCodec(failing)
+	_, err := om.GobEncode()
+	assert.ErrorIs(t, err, errCodec)
+
+	om.SetKeyCodec(coordCodec)
+	b, err := om.GobEncode()
+	assert.NoError(t, err)
+	decoded := ordered.NewMap[coord, int]()
+	decoded.SetKeyCodec(failing)
+	assert.ErrorIs(t, decoded.GobDecode(b), errCodec)
+}
@@ -0,0 +1,48 @@
+package ordered
+
+// ColumnBuilder appends values to a column. It matches the builders of
+// columnar formats, e.g. the typed builders of Apache Arrow, so that they
+// can be filled by AppendColumns directly.
+type ColumnBuilder[T any] interface {
+	// Reserve makes room for n more values.
+	Reserve(n int)
+	// Append appends a value to the column.
+	Append(v T)
+}
+
+// ToColumns returns the keys and the values of the map as two slices
+// according to the insertion order, i.e. the i-th key is mapped to the i-th
+// value. The map is walked once.
+func (o *Map[K, V]) ToColumns() ([]K, []V) {
+	keys := make([]K, o.items.Len())
+	values := make([]V, o.items.Len())
+	idx := 0
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		key, vp := o.entry(e)
+		keys[idx] = key
+		values[idx] = vp.value
+		idx++
+	}
+	return keys, values
+}
+
+// AppendColumns appends the keys and the values of the map to the given
+// column builders according to the insertion order. Either builder can be
+// nil to skip its column.
+func (o *Map[K, V]) AppendColumns(keys ColumnBuilder[K], values ColumnBuilder[V]) {
+	if keys != nil {
+		keys.Reserve(o.items.Len())
+	}
+	if values != nil {
+		values.Reserve(o.items.Len())
+	}
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		key, vp := o.entry(e)
+		if keys != nil {
+			keys.Append(key)
+		}
+		if values != nil {
+			values.Append(vp.value)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package ordered_test
+
+import (
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+type sliceBuilder[T any] struct {
+	reserved int
+	values   []T
+}
+
+func (b *sliceBuilder[T]) Reserve(n int) {
+	b.reserved += n
+	b.values = append(make([]T, 0, len(b.values)+n), b.values...)
+}
+
+func (b *sliceBuilder[T]) Append(v T) {
+	b.values = append(b.values, v)
+}
+
+func TestToColumns(t *testing.T) {
+	type kv = ordered.KeyValue[string, float64]
+	om := ordered.NewMapWithKVs(kv{"c", 0.3}, kv{"a", 0.1}, kv{"b", 0.2})
+
+	keys, values := om.ToColumns()
+	assert.Equal(t, []string{"c", "a", "b"}, keys)
+	assert.Equal(t, []float64{0.3, 0.1, 0.2}, values)
+
+	keys, values = ordered.NewMap[string, float64]().ToColumns()
+	assert.Empty(t, keys)
+	assert.Empty(t, values)
+}
+
+func TestAppendColumns(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs(kv{"x", 1}, kv{"y", 2})
+
+	keys := &sliceBuilder[string]{values: []string{"w"}}
+	values := &sliceBuilder[int]{}
+	om.AppendColumns(keys, values)
+	assert.Equal(t, []string{"w", "x", "y"}, keys.values)
+	assert.Equal(t, []int{1, 2}, values.values)
+	assert.Equal(t, 2, keys.reserved)
+
+	values = &sliceBuilder[int]{}
+	om.AppendColumns(nil, values)
+	assert.Equal(t, []int{1, 2}, values.values)
+}
@@ -0,0 +1,54 @@
+package ordered
+
+// ComparableMap wraps an ordered map whose values are comparable and adds
+// the operations which need to compare the values, e.g. entry level lookup
+// and removal. All the methods of the wrapped map are available on it.
+type ComparableMap[K comparable, V comparable] struct {
+	*Map[K, V]
+}
+
+// NewComparableMap initializes an ordered map with comparable values
+// configured by the given options.
+func NewComparableMap[K comparable, V comparable](opts ...Option) *ComparableMap[K, V] {
+	return &ComparableMap[K, V]{Map: NewMap[K, V](opts...)}
+}
+
+// ContainsEntry checks if the map maps the given key to the given value.
+func (o *ComparableMap[K, V]) ContainsEntry(key K, value V) bool {
+	if vp, ok := o.lookup(key); ok {
+		return vp.value == value
+	}
+	return false
+}
+
+// ContainsValue checks if any key of the map is mapped to the given value.
+func (o *ComparableMap[K, V]) ContainsValue(value V) bool {
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		if _, vp := o.entry(e); vp.value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveEntry removes the key only if it is mapped to the given value. The
+// returned boolean value indicates whether the entry is removed or not.
+func (o *ComparableMap[K, V]) RemoveEntry(key K, value V) bool {
+	if !o.ContainsEntry(key, value) {
+		return false
+	}
+	o.Remove(key)
+	return true
+}
+
+// EntrySet returns the key-value pairs of the map as an ordered set
+// according to their insertion order. The returned set is a snapshot and
+// changing it does not affect the map.
+func (o *ComparableMap[K, V]) EntrySet() *Set[KeyValue[K, V]] {
+	s := NewSetWithCapacity[KeyValue[K, V]](o.Len())
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		key, vp := o.entry(e)
+		s.Add(KeyValue[K, V]{Key: key, Value: vp.value})
+	}
+	return s
+}
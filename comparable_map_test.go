@@ -0,0 +1,79 @@
+package ordered_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComparableMapContainsEntry(t *testing.T) {
+	om := ordered.NewComparableMap[string, int]()
+	om.Put("foo", 1)
+	om.Put("bar", 2)
+
+	assert.True(t, om.ContainsEntry("foo", 1))
+	assert.False(t, om.ContainsEntry("foo", 2))
+	assert.False(t, om.ContainsEntry("baz", 0))
+}
+
+func TestComparableMapContainsValue(t *testing.T) {
+	om := ordered.NewComparableMap[string, int]()
+	om.Put("foo", 1)
+	om.Put("bar", 2)
+
+	assert.True(t, om.ContainsValue(2))
+	assert.False(t, om.ContainsValue(3))
+}
+
+func TestComparableMapRemoveEntry(t *testing.T) {
+	om := ordered.NewComparableMap[string, int]()
+	om.Put("foo", 1)
+	om.Put("bar", 2)
+
+	assert.False(t, om.RemoveEntry("foo", 2))
+	assert.True(t, om.ContainsKey("foo"))
+
+	assert.True(t, om.RemoveEntry("foo", 1))
+	assert.False(t, om.ContainsKey("foo"))
+	assert.Equal(t, []string{"bar"}, om.Keys())
+}
+
+func TestComparableMapEntrySet(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewComparableMap[string, int]()
+	om.Put("foo", 1)
+	om.Put("bar", 2)
+	om.Put("foo", 3)
+
+	es := om.EntrySet()
+	assert.Equal(t, []kv{{"foo", 3}, {"bar", 2}}, es.Elements())
+	assert.True(t, es.Contains(kv{"bar", 2}))
+	assert.False(t, es.Contains(kv{"foo", 1}))
+
+	es.Remove(kv{"bar", 2})
+	assert.True(t, om.ContainsKey("bar"))
+}
+
+func TestComparableMapOptions(t *testing.T) {
+	om := ordered.NewComparableMap[string, int](ordered.WithMaxEntries(1, ordered.PolicyReject), ordered.WithStats())
+	om.Put("foo", 1)
+	om.Put("bar", 2)
+
+	assert.True(t, om.ContainsEntry("foo", 1))
+	assert.False(t, om.ContainsKey("bar"))
+	assert.Equal(t, ordered.Stats{Len: 1, Puts: 1, Gets: 1, Misses: 1}, om.Stats())
+}
+
+func TestComparableMapWrap(t *testing.T) {
+	type kv = ordered.KeyValue[string, bool]
+	om := ordered.NewMapWithKVs[string, bool](kv{"foo", true}, kv{"bar", false})
+	cm := ordered.ComparableMap[string, bool]{Map: om}
+
+	assert.True(t, cm.ContainsEntry("bar", false))
+
+	b, err := json.Marshal(cm)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"foo":true,"bar":false}`, string(b))
+}
@@ -0,0 +1,153 @@
+package ordered
+
+import (
+	"container/list"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// PublishMap registers the map with the expvar package under the given
+// name. The published variable renders the map as JSON according to the
+// insertion order every time it is read. Like expvar.Publish, it panics
+// if the name is already registered.
+//
+// The variable is read by the goroutines serving the expvar page, so it is
+// read while holding mu, which must guard the map against its writers e.g.
+// the RLocker of the sync.RWMutex they lock. mu can be nil only if the map
+// is never modified after it is published. Otherwise, reading the variable
+// races with the writers and may crash the program.
+func PublishMap[K comparable, V any](name string, om *Map[K, V], mu sync.Locker) {
+	expvar.Publish(name, jsonVar{om, mu})
+}
+
+// PublishSet registers the set with the expvar package under the given
+// name. The published variable renders the set as JSON according to the
+// insertion order every time it is read. Like expvar.Publish, it panics
+// if the name is already registered. mu guards the reads of the set as
+// described in PublishMap.
+func PublishSet[T comparable](name string, s *Set[T], mu sync.Locker) {
+	expvar.Publish(name, jsonVar{s, mu})
+}
+
+// jsonVar implements expvar.Var for any json.Marshaler.
+type jsonVar struct {
+	m  json.Marshaler
+	mu sync.Locker
+}
+
+// String returns the JSON representation of the underlying value as
+// required by expvar.Var.
+func (v jsonVar) String() string {
+	var b []byte
+	var err error
+	withLock(v.mu, func() { b, err = v.m.MarshalJSON() })
+	if err != nil {
+		return strconv.Quote(err.Error())
+	}
+	return string(b)
+}
+
+// MapHandler returns an http.Handler which serves the map as JSON according
+// to the insertion order. The optional offset and limit query parameters
+// select a page of the map and the total number of elements is reported
+// in the X-Total-Count header. Only the entries of the page are copied
+// while holding mu, which must guard the map against its writers as
+// described in PublishMap.
+func MapHandler[K comparable, V any](om *Map[K, V], mu sync.Locker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, limit, ok := pageQuery(w, r)
+		if !ok {
+			return
+		}
+		var kvs []KeyValue[K, V]
+		total := 0
+		withLock(mu, func() {
+			total = om.Len()
+			om.walkPage(offset, limit, func(e *list.Element) {
+				key, vp := om.entry(e)
+				kvs = append(kvs, KeyValue[K, V]{Key: key, Value: vp.value})
+			})
+		})
+		page := NewMapWithKVs(kvs...)
+		// the page is marshalled like the map itself
+		page.cfg.keyTransform = om.cfg.keyTransform
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		WriteJSON(w, http.StatusOK, page)
+	})
+}
+
+// SetHandler returns an http.Handler which serves the set as JSON according
+// to the insertion order. The optional offset and limit query parameters
+// select a page of the set and the total number of elements is reported
+// in the X-Total-Count header. Only the elements of the page are copied
+// while holding mu as described in MapHandler.
+func SetHandler[T comparable](s *Set[T], mu sync.Locker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, limit, ok := pageQuery(w, r)
+		if !ok {
+			return
+		}
+		var elems []T
+		total := 0
+		withLock(mu, func() {
+			total = s.Len()
+			s.mp.walkPage(offset, limit, func(e *list.Element) {
+				elems = append(elems, s.mp.keyOf(e))
+			})
+		})
+		page := NewSetWithElems(elems...)
+		page.enc = s.enc
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		WriteJSON(w, http.StatusOK, page)
+	})
+}
+
+// walkPage invokes f for the elements of the map from the given offset up
+// to the given limit. A negative limit means no limit.
+func (o *Map[K, V]) walkPage(offset, limit int, f func(*list.Element)) {
+	e := o.items.Front()
+	for ; e != nil && offset > 0; offset-- {
+		e = e.Next()
+	}
+	for ; e != nil && limit != 0; limit-- {
+		f(e)
+		e = e.Next()
+	}
+}
+
+// withLock calls f while holding mu if it is not nil.
+func withLock(mu sync.Locker, f func()) {
+	if mu != nil {
+		mu.Lock()
+		defer mu.Unlock()
+	}
+	f()
+}
+
+// pageQuery parses the offset and limit query parameters. The limit is
+// negative if it is not given. It writes a bad request response and
+// returns false if any of the parameters is invalid.
+func pageQuery(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	offset, limit := 0, -1
+	query := r.URL.Query()
+	if s := query.Get("offset"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return 0, 0, false
+		}
+		offset = n
+	}
+	if s := query.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return 0, 0, false
+		}
+		limit = n
+	}
+	return offset, limit, true
+}
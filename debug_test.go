@@ -0,0 +1,153 @@
+package ordered_test
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingLocker counts the calls of Lock and Unlock.
+type countingLocker struct {
+	sync.Mutex
+	locks, unlocks int
+}
+
+func (l *countingLocker) Lock() {
+	l.Mutex.Lock()
+	l.locks++
+}
+
+func (l *countingLocker) Unlock() {
+	l.unlocks++
+	l.Mutex.Unlock()
+}
+
+// publishSeq makes the expvar names unique across the runs of a test since
+// expvar cannot unregister a name.
+var publishSeq int
+
+func publishName(prefix string) string {
+	publishSeq++
+	return prefix + "-" + strconv.Itoa(publishSeq)
+}
+
+func TestPublishMap(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs[string, int](kv{"foo", 1}, kv{"bar", 2})
+	mu := &countingLocker{}
+	name := publishName("test-publish-map")
+	ordered.PublishMap(name, om, mu)
+
+	v := expvar.Get(name)
+	assert.NotNil(t, v)
+	assert.Equal(t, `{"foo":1,"bar":2}`, v.String())
+
+	om.Put("baz", 3)
+	assert.Equal(t, `{"foo":1,"bar":2,"baz":3}`, v.String())
+	assert.Equal(t, 2, mu.locks)
+	assert.Equal(t, 2, mu.unlocks)
+
+	assert.Panics(t, func() {
+		ordered.PublishMap(name, om, nil)
+	})
+}
+
+func TestPublishSet(t *testing.T) {
+	s := ordered.NewSetWithElems[string]("foo", "bar")
+	name := publishName("test-publish-set")
+	ordered.PublishSet(name, s, nil)
+
+	v := expvar.Get(name)
+	assert.NotNil(t, v)
+	assert.Equal(t, `["foo","bar"]`, v.String())
+
+	s.Remove("foo")
+	assert.Equal(t, `["bar"]`, v.String())
+}
+
+func TestMapHandler(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs[string, int](kv{"a", 1}, kv{"b", 2}, kv{"c", 3}, kv{"d", 4})
+	mu := &countingLocker{}
+	handler := ordered.MapHandler(om, mu)
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	t.Run("whole map", func(t *testing.T) {
+		rec := serve("/")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "4", rec.Header().Get("X-Total-Count"))
+		assert.Equal(t, `{"a":1,"b":2,"c":3,"d":4}`, rec.Body.String())
+		assert.Equal(t, 1, mu.locks)
+		assert.Equal(t, 1, mu.unlocks)
+	})
+
+	t.Run("paginated map", func(t *testing.T) {
+		rec := serve("/?offset=1&limit=2")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"b":2,"c":3}`, rec.Body.String())
+
+		rec = serve("/?offset=3&limit=10")
+		assert.Equal(t, `{"d":4}`, rec.Body.String())
+
+		rec = serve("/?offset=10")
+		assert.Equal(t, `{}`, rec.Body.String())
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve("/?offset=-1").Code)
+		assert.Equal(t, http.StatusBadRequest, serve("/?limit=abc").Code)
+	})
+
+	t.Run("key transform", func(t *testing.T) {
+		om := ordered.NewMap[string, int](ordered.WithKeyTransform(strings.ToUpper))
+		om.Put("a", 1)
+		om.Put("b", 2)
+
+		rec := httptest.NewRecorder()
+		ordered.MapHandler(om, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?limit=1", nil))
+		assert.Equal(t, `{"A":1}`, rec.Body.String())
+	})
+
+	t.Run("marshalling error", func(t *testing.T) {
+		om := ordered.NewMap[errKey, int]()
+		om.Put(errKey{}, 1)
+
+		rec := httptest.NewRecorder()
+		ordered.MapHandler(om, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestSetHandler(t *testing.T) {
+	s := ordered.NewSetWithElems[int](5, 3, 1, 4)
+	handler := ordered.SetHandler(s, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `[5,3,1,4]`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?limit=2", nil))
+	assert.Equal(t, "4", rec.Header().Get("X-Total-Count"))
+	assert.Equal(t, `[5,3]`, rec.Body.String())
+
+	ss := ordered.NewSetWithElems[shout]("foo", "bar")
+	ss.SetElementEncoding(ordered.AsJSON)
+	rec = httptest.NewRecorder()
+	ordered.SetHandler(ss, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, `["foo","bar"]`, rec.Body.String())
+}
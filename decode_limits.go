@@ -0,0 +1,92 @@
+package ordered
+
+import "fmt"
+
+// DecodeLimitError is returned by UnmarshalJSON if the input exceeds a limit
+// set by WithDecodeMaxDepth or WithDecodeMaxEntries.
+type DecodeLimitError struct {
+	// Limit is the name of the exceeded limit, either "depth" or "entries".
+	Limit string
+	// Max is the configured maximum of the limit.
+	Max int
+}
+
+func (e *DecodeLimitError) Error() string {
+	return fmt.Sprintf("decoding exceeds max %s of %d", e.Limit, e.Max)
+}
+
+// WithDecodeMaxDepth limits the nesting depth of the JSON input accepted by
+// UnmarshalJSON of the map or set. The outermost object or array has depth
+// 1. A non-positive n means no limit.
+//
+// The decode limits only protect the maps and sets initialized with them.
+// Decoding into a zero value Map or Set, e.g. a field of a request struct,
+// is not limited, so such a field must be initialized before decoding
+// untrusted input.
+func WithDecodeMaxDepth(n int) Option {
+	return func(c *config) {
+		c.decodeMaxDepth = n
+	}
+}
+
+// WithDecodeMaxEntries limits the number of entries of the JSON input
+// accepted by UnmarshalJSON of the map or set. Every member of an object
+// and every element of an array is counted at all the nesting levels, so
+// {"a":[1,2]} has 3 entries. A non-positive n means no limit.
+func WithDecodeMaxEntries(n int) Option {
+	return func(c *config) {
+		c.decodeMaxEntries = n
+	}
+}
+
+// checkLimits returns a DecodeLimitError if the nesting depth or the number
+// of entries of the JSON input exceeds the configured maximum. The input is
+// scanned before it is decoded, so oversized payloads are rejected without
+// decoding them.
+func (c *config) checkLimits(b []byte) error {
+	if c.decodeMaxDepth <= 0 && c.decodeMaxEntries <= 0 {
+		return nil
+	}
+	depth, entries := 0, 0
+	inString, escaped, opened := false, false, false
+	for _, ch := range b {
+		if opened && !isSpace(ch) {
+			// the first entry of a non-empty object or array
+			opened = false
+			if ch != '}' && ch != ']' {
+				entries++
+			}
+		}
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			if ch == '\\' {
+				escaped = true
+			} else if ch == '"' {
+				inString = false
+			}
+		case ch == '"':
+			inString = true
+		case ch == '{' || ch == '[':
+			depth++
+			opened = true
+			if c.decodeMaxDepth > 0 && depth > c.decodeMaxDepth {
+				return &DecodeLimitError{Limit: "depth", Max: c.decodeMaxDepth}
+			}
+		case ch == '}' || ch == ']':
+			depth--
+		case ch == ',':
+			// every other entry follows a comma
+			entries++
+		}
+		if c.decodeMaxEntries > 0 && entries > c.decodeMaxEntries {
+			return &DecodeLimitError{Limit: "entries", Max: c.decodeMaxEntries}
+		}
+	}
+	return nil
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
@@ -0,0 +1,85 @@
+package ordered_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeMaxDepth(t *testing.T) {
+	t.Run("map", func(t *testing.T) {
+		om := ordered.NewMap[string, any](ordered.WithDecodeMaxDepth(2))
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"a":[1,2],"b":{"c":"[[{{"}}`), om))
+		assert.Equal(t, []string{"a", "b"}, om.Keys())
+
+		err := json.Unmarshal([]byte(`{"a":[[1]]}`), om)
+		var limitErr *ordered.DecodeLimitError
+		assert.True(t, errors.As(err, &limitErr))
+		assert.Equal(t, "depth", limitErr.Limit)
+		assert.Equal(t, 2, limitErr.Max)
+	})
+
+	t.Run("escaped quote in string", func(t *testing.T) {
+		om := ordered.NewMap[string, string](ordered.WithDecodeMaxDepth(1))
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"a":"\"[[["}`), om))
+	})
+
+	t.Run("set", func(t *testing.T) {
+		s := ordered.NewSet[string](ordered.WithDecodeMaxDepth(1))
+
+		assert.NoError(t, json.Unmarshal([]byte(`["a","b"]`), s))
+
+		err := json.Unmarshal([]byte(`[["a"]]`), s)
+		var limitErr *ordered.DecodeLimitError
+		assert.True(t, errors.As(err, &limitErr))
+		assert.Equal(t, "depth", limitErr.Limit)
+	})
+}
+
+func TestDecodeMaxEntries(t *testing.T) {
+	t.Run("map", func(t *testing.T) {
+		om := ordered.NewMap[string, int](ordered.WithDecodeMaxEntries(2))
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"a":1,"b":2}`), om))
+
+		om.Clear()
+		err := json.Unmarshal([]byte(`{"a":1,"b":2,"c":3}`), om)
+		var limitErr *ordered.DecodeLimitError
+		assert.True(t, errors.As(err, &limitErr))
+		assert.Equal(t, "entries", limitErr.Limit)
+		assert.Equal(t, 2, limitErr.Max)
+		assert.EqualError(t, err, "decoding exceeds max entries of 2")
+	})
+
+	t.Run("nested entries", func(t *testing.T) {
+		om := ordered.NewMap[string, []int](ordered.WithDecodeMaxEntries(4))
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"a":[1,2], "b":[]}`), om))
+
+		om.Clear()
+		err := json.Unmarshal([]byte(`{"a":[1,2,3,4,5,6,7,8,9]}`), om)
+		var limitErr *ordered.DecodeLimitError
+		assert.True(t, errors.As(err, &limitErr))
+		assert.Equal(t, "entries", limitErr.Limit)
+		assert.True(t, om.IsEmpty())
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"a,b,c,d":[ ]}`), om))
+	})
+
+	t.Run("set", func(t *testing.T) {
+		s := ordered.NewSet[int](ordered.WithDecodeMaxEntries(3))
+
+		assert.NoError(t, json.Unmarshal([]byte(`[1,2,3]`), s))
+
+		s.Clear()
+		err := json.Unmarshal([]byte(`[1,1,1,1]`), s)
+		var limitErr *ordered.DecodeLimitError
+		assert.True(t, errors.As(err, &limitErr))
+		assert.Equal(t, 3, limitErr.Max)
+	})
+}
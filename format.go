@@ -0,0 +1,91 @@
+package ordered
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatOptions configures the string representation of a map or set
+// returned by FormatString.
+type FormatOptions struct {
+	// Separator is written between the elements.
+	Separator string
+	// KeyValueDelimiter is written between a key and its mapped value.
+	KeyValueDelimiter string
+	// Limit is the maximum number of elements written. The remaining
+	// elements are summarized by their count e.g. "…+998". Zero or a
+	// negative limit writes all the elements.
+	Limit int
+	// QuoteStrings writes the string keys, values and elements as Go
+	// quoted strings.
+	QuoteStrings bool
+}
+
+// DefaultFormatOptions returns the options used by String.
+func DefaultFormatOptions() FormatOptions {
+	return FormatOptions{
+		Separator:         " ",
+		KeyValueDelimiter: ":",
+	}
+}
+
+// FormatString returns the string representation of the map formatted
+// according to the given options. Only the written elements are visited, so
+// a limit keeps it cheap for large maps.
+func (o *Map[K, V]) FormatString(opts FormatOptions) string {
+	var sb strings.Builder
+	sb.WriteString("map{")
+	idx := 0
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		if idx > 0 {
+			sb.WriteString(opts.Separator)
+		}
+		if opts.Limit > 0 && idx == opts.Limit {
+			writeRest(&sb, o.items.Len()-idx)
+			break
+		}
+		key, vp := o.entry(e)
+		writeElem(&sb, key, opts.QuoteStrings)
+		sb.WriteString(opts.KeyValueDelimiter)
+		writeElem(&sb, vp.value, opts.QuoteStrings)
+		idx++
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// FormatString returns the string representation of the set formatted
+// according to the given options. The key-value delimiter is not used.
+func (s *Set[T]) FormatString(opts FormatOptions) string {
+	var sb strings.Builder
+	sb.WriteString("set{")
+	idx := 0
+	for e := s.mp.items.Front(); e != nil; e = e.Next() {
+		if idx > 0 {
+			sb.WriteString(opts.Separator)
+		}
+		if opts.Limit > 0 && idx == opts.Limit {
+			writeRest(&sb, s.mp.items.Len()-idx)
+			break
+		}
+		writeElem(&sb, s.mp.keyOf(e), opts.QuoteStrings)
+		idx++
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+func writeElem(sb *strings.Builder, v any, quote bool) {
+	if str, ok := v.(string); ok && quote {
+		sb.WriteString(strconv.Quote(str))
+		return
+	}
+	sb.WriteString(fmt.Sprint(v))
+}
+
+// writeRest writes the count of the elements left out by the limit.
+func writeRest(sb *strings.Builder, n int) {
+	sb.WriteString("…+")
+	sb.WriteString(strconv.Itoa(n))
+}
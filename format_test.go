@@ -0,0 +1,57 @@
+package ordered_test
+
+import (
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapFormatString(t *testing.T) {
+	om := ordered.NewMap[string, int]()
+	for i, key := range []string{"a", "b", "c", "d"} {
+		om.Put(key, i+1)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		assert.Equal(t, om.String(), om.FormatString(ordered.DefaultFormatOptions()))
+		assert.Equal(t, "map{a:1 b:2 c:3 d:4}", om.String())
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		opts := ordered.DefaultFormatOptions()
+		opts.Limit = 2
+		assert.Equal(t, "map{a:1 b:2 …+2}", om.FormatString(opts))
+
+		opts.Limit = 4
+		assert.Equal(t, "map{a:1 b:2 c:3 d:4}", om.FormatString(opts))
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		opts := ordered.FormatOptions{
+			Separator:         ", ",
+			KeyValueDelimiter: "=",
+			Limit:             3,
+			QuoteStrings:      true,
+		}
+		assert.Equal(t, `map{"a"=1, "b"=2, "c"=3, …+1}`, om.FormatString(opts))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		opts := ordered.DefaultFormatOptions()
+		opts.Limit = 1
+		assert.Equal(t, "map{}", ordered.NewMap[int, int]().FormatString(opts))
+	})
+}
+
+func TestSetFormatString(t *testing.T) {
+	s := ordered.NewSetWithElems("x", "y z", "w")
+
+	opts := ordered.DefaultFormatOptions()
+	assert.Equal(t, "set{x y z w}", s.FormatString(opts))
+
+	opts.Separator = ","
+	opts.QuoteStrings = true
+	opts.Limit = 2
+	assert.Equal(t, `set{"x","y z",…+1}`, s.FormatString(opts))
+}
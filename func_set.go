@@ -0,0 +1,150 @@
+package ordered
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/buger/jsonparser"
+)
+
+// FuncSet is an ordered set whose elements are hashed and compared by the
+// functions given at construction instead of ==. It allows elements whose
+// type is not comparable e.g. slices, or elements with value equality
+// semantics e.g. pointers to maps. It preserves the insertion order of the
+// elements like Set.
+type FuncSet[T any] struct {
+	hm *hashMap[T, struct{}]
+}
+
+// NewSetFunc initializes an ordered set which hashes the elements by the
+// given hash function and compares them by the given equal function. Equal
+// elements must have the same hash.
+func NewSetFunc[T any](hash func(T) uint64, equal func(a, b T) bool) *FuncSet[T] {
+	return &FuncSet[T]{
+		hm: newHashMap[T, struct{}](
+			func(e T) any { return hash(e) },
+			equal,
+		),
+	}
+}
+
+// NewSetFuncWithElems initializes an ordered set like NewSetFunc and adds
+// the elements in the set.
+func NewSetFuncWithElems[T any](hash func(T) uint64, equal func(a, b T) bool, elems ...T) *FuncSet[T] {
+	s := NewSetFunc(hash, equal)
+	for _, e := range elems {
+		s.Add(e)
+	}
+	return s
+}
+
+// Add inserts a new element in the set. If an equal element already exists,
+// the set is not changed.
+func (s *FuncSet[T]) Add(elem T) {
+	s.hm.put(elem, dummy)
+}
+
+// Contains checks whether the set contains an element equal to the given
+// element or not.
+func (s *FuncSet[T]) Contains(elem T) bool {
+	_, ok := s.hm.get(elem)
+	return ok
+}
+
+// Remove removes the element equal to the given element from the set and
+// returns whether it existed or not.
+func (s *FuncSet[T]) Remove(elem T) bool {
+	_, ok := s.hm.remove(elem)
+	return ok
+}
+
+// Len returns the number of elements in the set.
+func (s *FuncSet[T]) Len() int {
+	return s.hm.len()
+}
+
+// Elements returns all the elements of the set according to their insertion
+// order. The first element of the slice is the oldest element in the set.
+func (s *FuncSet[T]) Elements() []T {
+	elems := make([]T, 0, s.hm.len())
+	s.hm.forEach(func(e T, _ struct{}) {
+		elems = append(elems, e)
+	})
+	return elems
+}
+
+// ForEach invokes the given function f for each element of the set.
+func (s *FuncSet[T]) ForEach(f func(T)) {
+	for _, e := range s.Elements() {
+		f(e)
+	}
+}
+
+// IsEmpty checks whether the set is empty or not.
+func (s *FuncSet[T]) IsEmpty() bool {
+	return s.hm.len() == 0
+}
+
+// Clear removes all the elements from the set.
+func (s *FuncSet[T]) Clear() {
+	s.hm.clear()
+}
+
+// String returns the string representation of the set.
+func (s *FuncSet[T]) String() string {
+	var sb strings.Builder
+	sb.WriteString("set{")
+	for idx, elem := range s.Elements() {
+		if idx > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(fmt.Sprint(elem))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (s FuncSet[T]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for idx, elem := range s.Elements() {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+		bytes, err := json.Marshal(elem)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(bytes)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. The set must be
+// initialized by NewSetFunc so that the hash and equal functions are known.
+// The elements equal to an earlier element are dropped.
+func (s *FuncSet[T]) UnmarshalJSON(b []byte) error {
+	if s.hm == nil {
+		return errors.New("uninitialized set")
+	}
+	var abortErr error
+	_, err := jsonparser.ArrayEach(b, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		if abortErr != nil {
+			return
+		}
+		var elem T
+		if abortErr = json.Unmarshal(rawValue(value, dataType), &elem); abortErr != nil {
+			return
+		}
+		s.Add(elem)
+	})
+	if err != nil {
+		return err
+	}
+	return abortErr
+}
@@ -0,0 +1,154 @@
+package ordered_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func hashInts(s []int) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	for _, i := range s {
+		fmt.Fprint(&h, i, ",")
+	}
+	return h.Sum64()
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hashOrderedMap(om *ordered.Map[string, int]) uint64 {
+	return hashString(om.String())
+}
+
+func equalOrderedMap(a, b *ordered.Map[string, int]) bool {
+	return a.String() == b.String()
+}
+
+func TestFuncSet(t *testing.T) {
+	s := ordered.NewSetFunc(hashInts, equalInts)
+	assert.True(t, s.IsEmpty())
+
+	s.Add([]int{1, 2})
+	s.Add([]int{3})
+	s.Add([]int{1, 2})
+	s.Add([]int{})
+
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, [][]int{{1, 2}, {3}, {}}, s.Elements())
+	assert.True(t, s.Contains([]int{3}))
+	assert.False(t, s.Contains([]int{2, 1}))
+	assert.Equal(t, "set{[1 2] [3] []}", s.String())
+
+	assert.True(t, s.Remove([]int{1, 2}))
+	assert.False(t, s.Remove([]int{1, 2}))
+
+	var elems [][]int
+	s.ForEach(func(e []int) {
+		elems = append(elems, e)
+	})
+	assert.Equal(t, [][]int{{3}, {}}, elems)
+
+	s.Clear()
+	assert.True(t, s.IsEmpty())
+}
+
+func TestFuncSetCollision(t *testing.T) {
+	s := ordered.NewSetFuncWithElems(
+		func([]int) uint64 { return 0 },
+		equalInts,
+		[]int{1}, []int{2}, []int{1}, []int{3},
+	)
+	assert.Equal(t, [][]int{{1}, {2}, {3}}, s.Elements())
+
+	s.Remove([]int{2})
+	assert.True(t, s.Contains([]int{3}))
+	assert.Equal(t, [][]int{{1}, {3}}, s.Elements())
+}
+
+func TestFuncSetOfMaps(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	s := ordered.NewSetFunc(hashOrderedMap, equalOrderedMap)
+	s.Add(ordered.NewMapWithKVs(kv{"a", 1}, kv{"b", 2}))
+	s.Add(ordered.NewMapWithKVs(kv{"a", 1}, kv{"b", 2}))
+	s.Add(ordered.NewMapWithKVs(kv{"b", 2}, kv{"a", 1}))
+	assert.Equal(t, 2, s.Len())
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"a":1,"b":2},{"b":2,"a":1}]`, string(b))
+
+	decoded := ordered.NewSetFunc(hashOrderedMap, equalOrderedMap)
+	assert.NoError(t, json.Unmarshal([]byte(`[{"a":1},{"a":1},{"b":2}]`), decoded))
+	assert.Equal(t, "set{map{a:1} map{b:2}}", decoded.String())
+}
+
+func TestFuncSetJSON(t *testing.T) {
+	s := ordered.NewSetFuncWithElems(hashInts, equalInts, []int{3, 1}, []int{2})
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `[[3,1],[2]]`, string(b))
+
+	decoded := ordered.NewSetFunc(hashInts, equalInts)
+	assert.NoError(t, json.Unmarshal([]byte(`[[3,1],[2],[3,1]]`), decoded))
+	assert.Equal(t, s.Elements(), decoded.Elements())
+
+	assert.Error(t, json.Unmarshal([]byte(`[[1],["a"]]`), decoded))
+	assert.Error(t, json.Unmarshal([]byte(`{}`), decoded))
+
+	var uninit ordered.FuncSet[[]int]
+	assert.Error(t, json.Unmarshal(b, &uninit))
+}
+
+func TestNestedJSON(t *testing.T) {
+	t.Run("map of sets", func(t *testing.T) {
+		om := ordered.NewMap[string, *ordered.Set[int]]()
+		om.Put("odd", ordered.NewSetWithElems(3, 1))
+		om.Put("even", ordered.NewSetWithElems(4, 2))
+
+		b, err := json.Marshal(om)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"odd":[3,1],"even":[4,2]}`, string(b))
+
+		decoded := ordered.NewMap[string, *ordered.Set[int]]()
+		assert.NoError(t, json.Unmarshal(b, decoded))
+		assert.Equal(t, "map{odd:set{3 1} even:set{4 2}}", decoded.String())
+	})
+
+	t.Run("map of maps", func(t *testing.T) {
+		in := `{"z":{"b":2,"a":1},"y":{}}`
+		decoded := ordered.NewMap[string, *ordered.Map[string, int]]()
+		assert.NoError(t, json.Unmarshal([]byte(in), decoded))
+		assert.Equal(t, []string{"b", "a"}, decoded.GetOrDefault("z", nil).Keys())
+
+		b, err := json.Marshal(decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, in, string(b))
+	})
+
+	t.Run("set of sets", func(t *testing.T) {
+		in := `[[2,1],[],[1,2]]`
+		var decoded ordered.Set[*ordered.Set[int]]
+		assert.NoError(t, json.Unmarshal([]byte(in), &decoded))
+		assert.Equal(t, 3, decoded.Len())
+
+		b, err := json.Marshal(decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, in, string(b))
+	})
+}
@@ -0,0 +1,142 @@
+// Package graph provides a directed graph whose nodes and edges keep their
+// insertion order, so that the algorithms on it are deterministic.
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+
+	"github.com/nhAnik/ordered"
+)
+
+// ErrCycle is returned by TopologicalSort if the graph has a cycle.
+var ErrCycle = errors.New("graph has a cycle")
+
+// Graph is a directed graph stored as an ordered adjacency map. The nodes
+// are ordered by their insertion and the neighbors of a node are ordered by
+// the insertion of their edges.
+type Graph[K comparable] struct {
+	adj *ordered.Map[K, *ordered.Set[K]]
+}
+
+// New initializes an empty graph.
+func New[K comparable]() *Graph[K] {
+	return &Graph[K]{adj: ordered.NewMap[K, *ordered.Set[K]]()}
+}
+
+// AddNode adds a node to the graph if it does not exist.
+func (g *Graph[K]) AddNode(node K) {
+	if !g.adj.ContainsKey(node) {
+		g.adj.Put(node, ordered.NewSet[K]())
+	}
+}
+
+// AddEdge adds a directed edge from one node to another. The nodes are
+// added to the graph if they do not exist.
+func (g *Graph[K]) AddEdge(from, to K) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.adj.GetOrDefault(from, nil).Add(to)
+}
+
+// RemoveEdge removes the directed edge from one node to another and returns
+// whether it existed or not.
+func (g *Graph[K]) RemoveEdge(from, to K) bool {
+	if out, ok := g.adj.Get(from); ok {
+		return out.Remove(to)
+	}
+	return false
+}
+
+// HasEdge checks whether the graph has the directed edge from one node to
+// another or not.
+func (g *Graph[K]) HasEdge(from, to K) bool {
+	out, ok := g.adj.Get(from)
+	return ok && out.Contains(to)
+}
+
+// ContainsNode checks whether the graph has the given node or not.
+func (g *Graph[K]) ContainsNode(node K) bool {
+	return g.adj.ContainsKey(node)
+}
+
+// Neighbors returns the nodes which the given node has an edge to according
+// to the insertion order of the edges.
+func (g *Graph[K]) Neighbors(node K) []K {
+	if out, ok := g.adj.Get(node); ok {
+		return out.Elements()
+	}
+	return []K{}
+}
+
+// Nodes returns all the nodes of the graph according to their insertion
+// order.
+func (g *Graph[K]) Nodes() []K {
+	return g.adj.Keys()
+}
+
+// Len returns the number of nodes in the graph.
+func (g *Graph[K]) Len() int {
+	return g.adj.Len()
+}
+
+// TopologicalSort returns the nodes ordered so that every node comes before
+// the nodes it has an edge to. Among the nodes which can come next, the one
+// inserted first is chosen, so the result is deterministic. It returns
+// ErrCycle if the graph has a cycle.
+func (g *Graph[K]) TopologicalSort() ([]K, error) {
+	nodes := g.adj.Keys()
+	index := make(map[K]int, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+	}
+	inDegree := make([]int, len(nodes))
+	g.adj.ForEach(func(_ K, out *ordered.Set[K]) {
+		out.ForEach(func(to K) {
+			inDegree[index[to]]++
+		})
+	})
+
+	ready := &minHeap{}
+	for i, d := range inDegree {
+		if d == 0 {
+			*ready = append(*ready, i)
+		}
+	}
+	sort.Ints(*ready)
+
+	sorted := make([]K, 0, len(nodes))
+	for ready.Len() > 0 {
+		node := nodes[heap.Pop(ready).(int)]
+		sorted = append(sorted, node)
+		g.adj.GetOrDefault(node, nil).ForEach(func(to K) {
+			i := index[to]
+			if inDegree[i]--; inDegree[i] == 0 {
+				heap.Push(ready, i)
+			}
+		})
+	}
+	if len(sorted) < len(nodes) {
+		return nil, ErrCycle
+	}
+	return sorted, nil
+}
+
+// minHeap is a min-heap of node indexes.
+type minHeap []int
+
+func (h minHeap) Len() int { return len(h) }
+
+func (h minHeap) Less(i, j int) bool { return h[i] < h[j] }
+
+func (h minHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *minHeap) Push(x any) { *h = append(*h, x.(int)) }
+
+func (h *minHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
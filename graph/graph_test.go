@@ -0,0 +1,90 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/nhAnik/ordered/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph(t *testing.T) {
+	g := graph.New[string]()
+	g.AddEdge("a", "c")
+	g.AddEdge("a", "b")
+	g.AddNode("d")
+	g.AddEdge("a", "c")
+
+	assert.Equal(t, 4, g.Len())
+	assert.Equal(t, []string{"a", "c", "b", "d"}, g.Nodes())
+	assert.Equal(t, []string{"c", "b"}, g.Neighbors("a"))
+	assert.Equal(t, []string{}, g.Neighbors("d"))
+	assert.Equal(t, []string{}, g.Neighbors("z"))
+	assert.True(t, g.HasEdge("a", "b"))
+	assert.False(t, g.HasEdge("b", "a"))
+	assert.True(t, g.ContainsNode("d"))
+	assert.False(t, g.ContainsNode("z"))
+
+	assert.True(t, g.RemoveEdge("a", "c"))
+	assert.False(t, g.RemoveEdge("a", "c"))
+	assert.False(t, g.RemoveEdge("z", "a"))
+	assert.Equal(t, []string{"b"}, g.Neighbors("a"))
+	assert.True(t, g.ContainsNode("c"))
+}
+
+func TestTopologicalSort(t *testing.T) {
+	t.Run("insertion order tie-break", func(t *testing.T) {
+		g := graph.New[string]()
+		g.AddNode("shirt")
+		g.AddNode("socks")
+		g.AddEdge("pants", "shoes")
+		g.AddEdge("socks", "shoes")
+		g.AddEdge("shirt", "tie")
+		g.AddEdge("tie", "jacket")
+		g.AddEdge("pants", "belt")
+		g.AddEdge("belt", "jacket")
+
+		sorted, err := g.TopologicalSort()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"shirt", "socks", "pants", "shoes", "tie", "belt", "jacket"}, sorted)
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		build := func() *graph.Graph[int] {
+			g := graph.New[int]()
+			for i := 9; i >= 0; i-- {
+				g.AddEdge(i, i/2)
+			}
+			g.RemoveEdge(0, 0)
+			return g
+		}
+		first, err := build().TopologicalSort()
+		assert.NoError(t, err)
+		for i := 0; i < 10; i++ {
+			sorted, _ := build().TopologicalSort()
+			assert.Equal(t, first, sorted)
+		}
+		assert.Equal(t, []int{9, 8, 4, 7, 6, 3, 5, 2, 1, 0}, first)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		sorted, err := graph.New[int]().TopologicalSort()
+		assert.NoError(t, err)
+		assert.Empty(t, sorted)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		g := graph.New[int]()
+		g.AddEdge(1, 2)
+		g.AddEdge(2, 3)
+		g.AddEdge(3, 1)
+		g.AddEdge(0, 1)
+
+		_, err := g.TopologicalSort()
+		assert.ErrorIs(t, err, graph.ErrCycle)
+
+		g = graph.New[int]()
+		g.AddEdge(1, 1)
+		_, err = g.TopologicalSort()
+		assert.ErrorIs(t, err, graph.ErrCycle)
+	})
+}
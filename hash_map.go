@@ -0,0 +1,99 @@
+package ordered
+
+import "container/list"
+
+type hashEntry[K any, V any] struct {
+	key   K
+	value V
+}
+
+// hashMap is the insertion ordered storage behind the maps and sets whose
+// keys are not necessarily comparable. The keys are grouped in buckets by
+// the comparable value returned by hash and equal resolves the collisions
+// inside a bucket.
+type hashMap[K any, V any] struct {
+	hash    func(K) any
+	equal   func(K, K) bool
+	buckets map[any][]*list.Element
+	items   *list.List
+}
+
+func newHashMap[K any, V any](hash func(K) any, equal func(K, K) bool) *hashMap[K, V] {
+	return &hashMap[K, V]{
+		hash:    hash,
+		equal:   equal,
+		buckets: make(map[any][]*list.Element),
+		items:   list.New(),
+	}
+}
+
+// find returns the bucket hash of the key and the element holding the key
+// if it exists.
+func (h *hashMap[K, V]) find(key K) (any, *list.Element) {
+	hash := h.hash(key)
+	for _, e := range h.buckets[hash] {
+		if h.equal(e.Value.(*hashEntry[K, V]).key, key) {
+			return hash, e
+		}
+	}
+	return hash, nil
+}
+
+func (h *hashMap[K, V]) put(key K, value V) {
+	hash, e := h.find(key)
+	if e != nil {
+		e.Value.(*hashEntry[K, V]).value = value
+		return
+	}
+	e = h.items.PushBack(&hashEntry[K, V]{key: key, value: value})
+	h.buckets[hash] = append(h.buckets[hash], e)
+}
+
+func (h *hashMap[K, V]) get(key K) (V, bool) {
+	if _, e := h.find(key); e != nil {
+		return e.Value.(*hashEntry[K, V]).value, true
+	}
+	var dummy V
+	return dummy, false
+}
+
+func (h *hashMap[K, V]) remove(key K) (V, bool) {
+	hash, e := h.find(key)
+	if e == nil {
+		var dummy V
+		return dummy, false
+	}
+	bucket := h.buckets[hash]
+	for i := range bucket {
+		if bucket[i] == e {
+			bucket[i] = bucket[len(bucket)-1]
+			bucket[len(bucket)-1] = nil
+			bucket = bucket[:len(bucket)-1]
+			break
+		}
+	}
+	if len(bucket) == 0 {
+		delete(h.buckets, hash)
+	} else {
+		h.buckets[hash] = bucket
+	}
+	h.items.Remove(e)
+	return e.Value.(*hashEntry[K, V]).value, true
+}
+
+func (h *hashMap[K, V]) len() int {
+	return h.items.Len()
+}
+
+func (h *hashMap[K, V]) clear() {
+	h.buckets = make(map[any][]*list.Element)
+	h.items.Init()
+}
+
+// forEach invokes f for each entry according to the insertion order.
+func (h *hashMap[K, V]) forEach(f func(K, V)) {
+	for e := h.items.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*hashEntry[K, V])
+		f(entry.key, entry.value)
+	}
+}
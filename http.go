@@ -0,0 +1,37 @@
+package ordered
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// WriteJSON writes the JSON encoding of v as the body of a response with the
+// given status code. Maps and sets keep their insertion order in the body,
+// also when nested in other values. The value is encoded before anything is
+// written, so if the encoding fails, an internal server error is responded
+// instead and the error is returned.
+func WriteJSON(w http.ResponseWriter, code int, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, err = w.Write(b)
+	return err
+}
+
+// JSONHandler returns an http.Handler which responds with the JSON encoding
+// of the value returned by f for the request like WriteJSON with the status
+// code 200. If f returns an error, an internal server error is responded.
+func JSONHandler(f func(r *http.Request) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v, err := f(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		WriteJSON(w, http.StatusOK, v)
+	})
+}
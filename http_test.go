@@ -0,0 +1,61 @@
+package ordered_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJSON(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+
+	t.Run("nested", func(t *testing.T) {
+		body := struct {
+			Data  *ordered.Map[string, int] `json:"data"`
+			Order *ordered.Set[string]      `json:"order"`
+		}{
+			Data:  ordered.NewMapWithKVs(kv{"z", 26}, kv{"a", 1}),
+			Order: ordered.NewSetWithElems("z", "a"),
+		}
+
+		rec := httptest.NewRecorder()
+		assert.NoError(t, ordered.WriteJSON(rec, http.StatusCreated, body))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `{"data":{"z":26,"a":1},"order":["z","a"]}`, rec.Body.String())
+	})
+
+	t.Run("marshalling error", func(t *testing.T) {
+		om := ordered.NewMap[errKey, int]()
+		om.Put(errKey{}, 1)
+
+		rec := httptest.NewRecorder()
+		assert.Error(t, ordered.WriteJSON(rec, http.StatusOK, om))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestJSONHandler(t *testing.T) {
+	type kv = ordered.KeyValue[string, string]
+	handler := ordered.JSONHandler(func(r *http.Request) (any, error) {
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			return nil, errors.New("missing name")
+		}
+		return ordered.NewMapWithKVs(kv{"name", name}, kv{"greeting", "hello"}), nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?name=bob", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"name":"bob","greeting":"hello"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "missing name")
+}
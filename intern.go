@@ -0,0 +1,11 @@
+//go:build go1.23
+
+package ordered
+
+import "unique"
+
+// intern returns the canonical copy of the string. The canonical copies
+// are shared by all the maps and are reclaimed once they are unused.
+func intern(s string) string {
+	return unique.Make(s).Value()
+}
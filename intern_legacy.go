@@ -0,0 +1,22 @@
+//go:build !go1.23
+
+package ordered
+
+import "sync"
+
+var internTable = struct {
+	sync.Mutex
+	strs map[string]string
+}{strs: make(map[string]string)}
+
+// intern returns the canonical copy of the string. The canonical copies
+// are shared by all the maps and are never reclaimed before Go 1.23.
+func intern(s string) string {
+	internTable.Lock()
+	defer internTable.Unlock()
+	if c, ok := internTable.strs[s]; ok {
+		return c
+	}
+	internTable.strs[s] = s
+	return s
+}
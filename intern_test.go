@@ -0,0 +1,64 @@
+//go:build go1.20
+
+package ordered_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func sameString(a, b string) bool {
+	return unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestWithKeyInterning(t *testing.T) {
+	om1 := ordered.NewMap[string, int](ordered.WithKeyInterning())
+	om2 := ordered.NewMap[string, int](ordered.WithKeyInterning())
+	om3 := ordered.NewMap[string, int]()
+
+	for _, om := range []*ordered.Map[string, int]{om1, om2, om3} {
+		om.Put(strings.Repeat("tenant-", 3), 1)
+	}
+	assert.Equal(t, om1.Keys(), om2.Keys())
+	assert.True(t, sameString(om1.Keys()[0], om2.Keys()[0]))
+	assert.False(t, sameString(om1.Keys()[0], om3.Keys()[0]))
+
+	t.Run("decoded keys", func(t *testing.T) {
+		om := ordered.NewMap[string, int](ordered.WithKeyInterning())
+		err := json.Unmarshal([]byte(`{"tenant-tenant-tenant-":2}`), om)
+
+		assert.NoError(t, err)
+		assert.True(t, sameString(om1.Keys()[0], om.Keys()[0]))
+	})
+
+	t.Run("set elements", func(t *testing.T) {
+		s := ordered.NewSet[string](ordered.WithKeyInterning())
+		s.Add(strings.Repeat("tenant-", 3))
+
+		assert.True(t, sameString(om1.Keys()[0], s.Elements()[0]))
+	})
+}
+
+func TestWithValueInterning(t *testing.T) {
+	om1 := ordered.NewMap[int, string](ordered.WithValueInterning())
+	om2 := ordered.NewMap[int, string](ordered.WithValueInterning())
+
+	om1.Put(1, strings.Repeat("value-", 3))
+	om2.Put(2, strings.Repeat("value-", 3))
+	om2.Put(3, strings.Repeat("value-", 3))
+
+	assert.True(t, sameString(om1.Values()[0], om2.Values()[0]))
+	assert.True(t, sameString(om2.Values()[0], om2.Values()[1]))
+
+	t.Run("non string values", func(t *testing.T) {
+		om := ordered.NewMap[string, []byte](ordered.WithKeyInterning(), ordered.WithValueInterning())
+		om.Put("foo", []byte("bar"))
+
+		assert.Equal(t, []byte("bar"), om.GetOrDefault("foo", nil))
+	})
+}
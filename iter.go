@@ -0,0 +1,118 @@
+//go:build go1.23
+
+package ordered
+
+import (
+	"container/list"
+	"iter"
+)
+
+// All returns an iterator over the keys and values of the map according to
+// their insertion order. The elements are visited lazily, so the iteration
+// can be stopped early without copying the map. Updating the value of an
+// existing key and removing the current key are allowed during iteration,
+// but the other insertions and removals are not.
+//
+//	for k, v := range om.All() {
+//		fmt.Println(k, v)
+//	}
+func (o *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		o.walk(false, func(key K, vp *valuePair[V]) bool {
+			return yield(key, vp.value)
+		})
+	}
+}
+
+// Backward returns an iterator over the keys and values of the map in the
+// reverse insertion order like All.
+func (o *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		o.walk(true, func(key K, vp *valuePair[V]) bool {
+			return yield(key, vp.value)
+		})
+	}
+}
+
+// KeysSeq returns an iterator over the keys of the map according to their
+// insertion order like All, e.g. for slices.Collect or slices.Sorted.
+func (o *Map[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		o.walk(false, func(key K, _ *valuePair[V]) bool {
+			return yield(key)
+		})
+	}
+}
+
+// ValuesSeq returns an iterator over the values of the map according to
+// their insertion order like All.
+func (o *Map[K, V]) ValuesSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		o.walk(false, func(_ K, vp *valuePair[V]) bool {
+			return yield(vp.value)
+		})
+	}
+}
+
+// Insert puts the keys and values from the given iterator in the map, e.g.
+// from maps.All of a builtin map or All of another ordered map.
+func (o *Map[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		o.Put(k, v)
+	}
+}
+
+// Insert puts the keys and values from the given iterator in the map like
+// Map.Insert and records the changes as a single version.
+func (o *VersionedMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	o.group(func() {
+		for k, v := range seq {
+			o.Put(k, v)
+		}
+	})
+}
+
+// walk invokes f for each element of the map until f returns false.
+func (o *Map[K, V]) walk(backward bool, f func(K, *valuePair[V]) bool) {
+	mods := o.guard.modCount()
+	e := o.items.Front()
+	if backward {
+		e = o.items.Back()
+	}
+	var next *list.Element
+	for ; e != nil; e = next {
+		// keep the next element, so that the current one can be removed
+		if backward {
+			next = e.Prev()
+		} else {
+			next = e.Next()
+		}
+		key, vp := o.entry(e)
+		if vp == nil || vp.elem != e {
+			// f removed the element along with the previous one, so the
+			// rest of the list cannot be reached from it anymore
+			return
+		}
+		if !f(key, vp) {
+			return
+		}
+		if _, ok := o.lookup(key); !ok {
+			mods++
+		}
+		o.guard.check(mods)
+	}
+}
+
+// All returns an iterator over the elements of the set according to their
+// insertion order. Removing the current element is allowed during
+// iteration, but the other additions and removals are not.
+func (s *Set[T]) All() iter.Seq[T] {
+	return s.mp.KeysSeq()
+}
+
+// Insert adds the elements from the given iterator to the set.
+func (s *Set[T]) Insert(seq iter.Seq[T]) {
+	for e := range seq {
+		s.Add(e)
+	}
+}
@@ -0,0 +1,142 @@
+//go:build go1.23
+
+package ordered_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapAll(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs[string, int](kv{"c", 3}, kv{"a", 1}, kv{"b", 2})
+
+	var kvs []kv
+	for k, v := range om.All() {
+		kvs = append(kvs, kv{k, v})
+	}
+	assert.Equal(t, om.KeyValues(), kvs)
+
+	kvs = nil
+	for k, v := range om.Backward() {
+		kvs = append(kvs, kv{k, v})
+	}
+	assert.Equal(t, []kv{{"b", 2}, {"a", 1}, {"c", 3}}, kvs)
+
+	assert.Equal(t, []string{"c", "a", "b"}, slices.Collect(om.KeysSeq()))
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Sorted(om.KeysSeq()))
+	assert.Equal(t, []int{3, 1, 2}, slices.Collect(om.ValuesSeq()))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, maps.Collect(om.All()))
+
+	t.Run("stop early", func(t *testing.T) {
+		var keys []string
+		for k := range om.KeysSeq() {
+			if k == "a" {
+				break
+			}
+			keys = append(keys, k)
+		}
+		assert.Equal(t, []string{"c"}, keys)
+	})
+
+	t.Run("remove current", func(t *testing.T) {
+		om := ordered.NewMapWithKVs[string, int](kv{"a", 1}, kv{"b", 2}, kv{"c", 3}, kv{"d", 4})
+		for k, v := range om.All() {
+			if v%2 == 0 {
+				om.Remove(k)
+			} else {
+				om.Put(k, v*10)
+			}
+		}
+		assert.Equal(t, []kv{{"a", 10}, {"c", 30}}, om.KeyValues())
+	})
+
+	t.Run("remove other", func(t *testing.T) {
+		om := ordered.NewMapFromKeysValues([]int{1, 2, 3}, []int{1, 2, 3})
+		var keys []int
+		assert.NotPanics(t, func() {
+			for k := range om.All() {
+				keys = append(keys, k)
+				if k == 1 {
+					om.Remove(1)
+					om.Remove(2)
+				}
+			}
+		})
+		assert.Equal(t, []int{1}, keys)
+		assert.Equal(t, []int{3}, om.Keys())
+
+		om.Put(4, 4)
+		assert.NotPanics(t, func() {
+			for v := range om.ValuesSeq() {
+				om.Remove(v)
+				om.Remove(4)
+				om.Put(4, 5)
+			}
+		})
+		assert.Equal(t, []int{5}, om.Values())
+	})
+
+	t.Run("race check", func(t *testing.T) {
+		om := ordered.NewMap[int, int](ordered.WithRaceCheck())
+		om.Put(1, 1)
+		om.Put(2, 2)
+		assert.NotPanics(t, func() {
+			for k := range om.KeysSeq() {
+				om.Remove(k)
+			}
+		})
+		assert.True(t, om.IsEmpty())
+
+		om.Put(1, 1)
+		assert.Panics(t, func() {
+			for k := range om.KeysSeq() {
+				om.Put(k+1, k)
+			}
+		})
+	})
+}
+
+func TestMapInsert(t *testing.T) {
+	om := ordered.NewMap[string, int]()
+	om.Put("z", 0)
+	om.Insert(maps.All(map[string]int{"a": 1}))
+
+	other := ordered.NewMap[string, int]()
+	other.Put("c", 3)
+	other.Put("z", 26)
+	om.Insert(other.All())
+
+	assert.Equal(t, []string{"z", "a", "c"}, om.Keys())
+	assert.Equal(t, []int{26, 1, 3}, om.Values())
+}
+
+func TestVersionedMapInsert(t *testing.T) {
+	vm := ordered.NewVersionedMap[string, int]()
+	vm.Put("a", 1)
+	vm.Insert(maps.All(map[string]int{"b": 2}))
+	assert.Equal(t, []string{"a", "b"}, vm.Keys())
+
+	assert.True(t, vm.Undo())
+	assert.Equal(t, []string{"a"}, vm.Keys())
+	assert.True(t, vm.Undo())
+	assert.False(t, vm.CanUndo())
+
+	vm.BeginVersion()
+	vm.Put("c", 3)
+	vm.Insert(maps.All(map[string]int{"d": 4}))
+	vm.Rollback()
+	assert.True(t, vm.IsEmpty())
+}
+
+func TestSetAllInsert(t *testing.T) {
+	s := ordered.NewSetWithElems(3, 1, 2)
+	assert.Equal(t, []int{3, 1, 2}, slices.Collect(s.All()))
+
+	s.Insert(slices.Values([]int{2, 5, 4}))
+	assert.Equal(t, []int{3, 1, 2, 5, 4}, s.Elements())
+}
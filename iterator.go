@@ -0,0 +1,66 @@
+package ordered
+
+import "container/list"
+
+// Iterator walks the elements of a map according to their insertion order
+// in both directions starting from a given key. Updating the value of an
+// existing key is allowed during iteration, but inserting or removing keys
+// invalidates the iterator. An invalidated iterator panics if the map is
+// created with the WithRaceCheck option.
+type Iterator[K comparable, V any] struct {
+	om   *Map[K, V]
+	elem *list.Element
+	mods uint64
+}
+
+// IterateFrom returns an iterator positioned at the given key in O(1) time.
+// The returned iterator is not valid if the key does not exist in the map.
+//
+//	for it := om.IterateFrom(key); it.Valid(); it.Next() {
+//		fmt.Println(it.Key(), it.Value())
+//	}
+func (o *Map[K, V]) IterateFrom(key K) *Iterator[K, V] {
+	it := &Iterator[K, V]{om: o, mods: o.guard.modCount()}
+	if vp, ok := o.lookup(key); ok {
+		it.elem = vp.elem
+	}
+	return it
+}
+
+// Valid checks whether the iterator is positioned at an element or not.
+func (it *Iterator[K, V]) Valid() bool {
+	return it.elem != nil
+}
+
+// Next moves the iterator to the next newer element. The iterator becomes
+// invalid after the newest element.
+func (it *Iterator[K, V]) Next() {
+	it.om.guard.check(it.mods)
+	if it.elem != nil {
+		it.elem = it.elem.Next()
+	}
+}
+
+// Prev moves the iterator to the previous older element. The iterator
+// becomes invalid before the oldest element.
+func (it *Iterator[K, V]) Prev() {
+	it.om.guard.check(it.mods)
+	if it.elem != nil {
+		it.elem = it.elem.Prev()
+	}
+}
+
+// Key returns the key at the current position. It panics if the iterator
+// is not valid.
+func (it *Iterator[K, V]) Key() K {
+	it.om.guard.check(it.mods)
+	return it.om.keyOf(it.elem)
+}
+
+// Value returns the mapped value at the current position. It panics if the
+// iterator is not valid.
+func (it *Iterator[K, V]) Value() V {
+	it.om.guard.check(it.mods)
+	_, vp := it.om.entry(it.elem)
+	return vp.value
+}
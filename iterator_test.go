@@ -0,0 +1,56 @@
+package ordered_test
+
+import (
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterateFrom(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs[string, int](kv{"a", 1}, kv{"b", 2}, kv{"c", 3}, kv{"d", 4})
+
+	t.Run("forward", func(t *testing.T) {
+		var kvs []kv
+		for it := om.IterateFrom("b"); it.Valid(); it.Next() {
+			kvs = append(kvs, kv{it.Key(), it.Value()})
+		}
+		assert.Equal(t, []kv{{"b", 2}, {"c", 3}, {"d", 4}}, kvs)
+	})
+
+	t.Run("backward", func(t *testing.T) {
+		var keys []string
+		for it := om.IterateFrom("c"); it.Valid(); it.Prev() {
+			keys = append(keys, it.Key())
+		}
+		assert.Equal(t, []string{"c", "b", "a"}, keys)
+	})
+
+	t.Run("change direction", func(t *testing.T) {
+		it := om.IterateFrom("a")
+		it.Next()
+		it.Next()
+		it.Prev()
+		assert.True(t, it.Valid())
+		assert.Equal(t, "b", it.Key())
+	})
+
+	t.Run("update value during iteration", func(t *testing.T) {
+		om := ordered.NewMapWithKVs[string, int](kv{"a", 1}, kv{"b", 2}, kv{"c", 3})
+		for it := om.IterateFrom("b"); it.Valid(); it.Next() {
+			om.Put(it.Key(), it.Value()*10)
+		}
+		assert.Equal(t, []int{1, 20, 30}, om.Values())
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		it := om.IterateFrom("z")
+		assert.False(t, it.Valid())
+
+		it.Next()
+		it.Prev()
+		assert.False(t, it.Valid())
+		assert.Panics(t, func() { it.Key() })
+	})
+}
@@ -0,0 +1,89 @@
+// Package metrics exports the operation counters of ordered maps and sets
+// as Prometheus metrics. The maps and sets must be created with the
+// ordered.WithStats option.
+package metrics
+
+import (
+	"sync"
+
+	"github.com/nhAnik/ordered"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Source is a named collection whose operation counters are exported.
+// Map and Set created with ordered.WithStats implement Source.
+type Source interface {
+	Stats() ordered.Stats
+}
+
+// Collector is a prometheus.Collector which reports the size and the
+// operation counters of the registered maps and sets. Each metric has a
+// name label holding the name the source is registered with.
+type Collector struct {
+	mu      sync.RWMutex
+	sources *ordered.Map[string, Source]
+
+	entries *prometheus.Desc
+	puts    *prometheus.Desc
+	gets    *prometheus.Desc
+	hits    *prometheus.Desc
+	misses  *prometheus.Desc
+	removes *prometheus.Desc
+}
+
+// NewCollector initializes a collector whose metric names are prefixed by
+// the given namespace.
+func NewCollector(namespace string) *Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "ordered", name), help, []string{"name"}, nil)
+	}
+	return &Collector{
+		sources: ordered.NewMap[string, Source](),
+		entries: desc("entries", "Number of entries."),
+		puts:    desc("puts_total", "Number of insertions and updates."),
+		gets:    desc("gets_total", "Number of lookups."),
+		hits:    desc("hits_total", "Number of lookups which found the key."),
+		misses:  desc("misses_total", "Number of lookups which did not find the key."),
+		removes: desc("removes_total", "Number of removed entries."),
+	}
+}
+
+// Register adds a source to the collector under the given name. A source
+// registered before under the same name is replaced.
+func (c *Collector) Register(name string, s Source) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sources.Put(name, s)
+}
+
+// Unregister removes the source registered under the given name.
+func (c *Collector) Unregister(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sources.Remove(name)
+}
+
+// Describe implements prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.entries
+	ch <- c.puts
+	ch <- c.gets
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.removes
+}
+
+// Collect implements prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.sources.ForEach(func(name string, s Source) {
+		stats := s.Stats()
+		ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(stats.Len), name)
+		ch <- prometheus.MustNewConstMetric(c.puts, prometheus.CounterValue, float64(stats.Puts), name)
+		ch <- prometheus.MustNewConstMetric(c.gets, prometheus.CounterValue, float64(stats.Gets), name)
+		ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits), name)
+		ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses), name)
+		ch <- prometheus.MustNewConstMetric(c.removes, prometheus.CounterValue, float64(stats.Removes), name)
+	})
+}
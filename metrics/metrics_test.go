@@ -0,0 +1,52 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/nhAnik/ordered/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	om := ordered.NewMap[string, int](ordered.WithStats())
+	om.Put("a", 1)
+	om.Put("b", 2)
+	om.Get("a")
+	om.Get("c")
+	om.Remove("b")
+
+	s := ordered.NewSet[int](ordered.WithStats())
+	s.Add(1)
+
+	c := metrics.NewCollector("app")
+	c.Register("users", om)
+	c.Register("ids", s)
+
+	expected := `
+# HELP app_ordered_entries Number of entries.
+# TYPE app_ordered_entries gauge
+app_ordered_entries{name="ids"} 1
+app_ordered_entries{name="users"} 1
+# HELP app_ordered_hits_total Number of lookups which found the key.
+# TYPE app_ordered_hits_total counter
+app_ordered_hits_total{name="ids"} 0
+app_ordered_hits_total{name="users"} 1
+# HELP app_ordered_misses_total Number of lookups which did not find the key.
+# TYPE app_ordered_misses_total counter
+app_ordered_misses_total{name="ids"} 0
+app_ordered_misses_total{name="users"} 1
+# HELP app_ordered_puts_total Number of insertions and updates.
+# TYPE app_ordered_puts_total counter
+app_ordered_puts_total{name="ids"} 1
+app_ordered_puts_total{name="users"} 2
+`
+	err := testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"app_ordered_entries", "app_ordered_hits_total", "app_ordered_misses_total", "app_ordered_puts_total")
+	assert.NoError(t, err)
+
+	c.Unregister("ids")
+	assert.Equal(t, 6, testutil.CollectAndCount(c))
+}
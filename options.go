@@ -0,0 +1,117 @@
+package ordered
+
+import "time"
+
+// Option configures an ordered map at construction.
+type Option func(*config)
+
+type config struct {
+	timestamps   bool
+	now          func() time.Time
+	internKeys   bool
+	internValues bool
+	maxEntries   int
+	policy       Policy
+	stats        bool
+	raceCheck    bool
+	keyTransform func(string) string
+	radixTree    bool
+
+	decodeMaxDepth   int
+	decodeMaxEntries int
+}
+
+func newConfig(opts []Option) config {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.now == nil {
+		cfg.now = time.Now
+	}
+	return cfg
+}
+
+// WithTimestamps records the time when each key is inserted and when its
+// mapped value is last updated. See Map.InsertedAt, Map.UpdatedAt and
+// Map.RangeInsertedBetween.
+func WithTimestamps() Option {
+	return func(c *config) {
+		c.timestamps = true
+	}
+}
+
+// WithClock sets the function used to read the current time. It defaults
+// to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(c *config) {
+		c.now = now
+	}
+}
+
+// WithKeyInterning deduplicates the string keys across all the maps and
+// sets created with this option, so that equal keys share the same backing
+// memory. It is meant for many maps holding lots of repeated keys. Keys of
+// other types are not affected.
+func WithKeyInterning() Option {
+	return func(c *config) {
+		c.internKeys = true
+	}
+}
+
+// WithValueInterning deduplicates the string values across all the maps
+// created with this option, so that equal values share the same backing
+// memory. Values of other types are not affected.
+func WithValueInterning() Option {
+	return func(c *config) {
+		c.internValues = true
+	}
+}
+
+// WithKeyTransform sets a function which transforms the string keys when
+// the map is marshalled to JSON, e.g. to convert them to snake_case. The
+// keys in the map are not changed and the keys are not transformed back by
+// UnmarshalJSON. Keys of other types are not affected. The function should
+// not map different keys to the same key, since the output would have
+// duplicate keys then.
+func WithKeyTransform(f func(string) string) Option {
+	return func(c *config) {
+		c.keyTransform = f
+	}
+}
+
+// WithStats counts the operations on the map or set. The counters are
+// available through the Stats method, e.g. for exporting them as metrics.
+func WithStats() Option {
+	return func(c *config) {
+		c.stats = true
+	}
+}
+
+// Policy decides what happens when a new key is put in a map which already
+// holds the maximum number of entries set by WithMaxEntries.
+type Policy int
+
+const (
+	// PolicyReject rejects the new key. Put ignores the key and PutE returns
+	// ErrFull. The existing keys can still be updated.
+	PolicyReject Policy = iota
+)
+
+// WithMaxEntries limits the number of entries of the map or set to n and
+// applies the given policy when the limit is hit. A non-positive n means
+// no limit.
+func WithMaxEntries(n int, policy Policy) Option {
+	return func(c *config) {
+		c.maxEntries = n
+		c.policy = policy
+	}
+}
+
+// internValue returns the canonical copy of v if it is a string.
+func internValue[T any](v T) T {
+	if s, ok := any(v).(string); ok {
+		return any(intern(s)).(T)
+	}
+	return v
+}
@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/buger/jsonparser"
 )
@@ -16,6 +15,18 @@ import (
 type valuePair[V any] struct {
 	elem  *list.Element
 	value V
+	times *entryTimes
+}
+
+// keyIndex maps the keys of a Map to their pairs in place of the builtin
+// map. The list element of a pair holds whatever the index sets, which the
+// index maps back to the key and the pair.
+type keyIndex[K comparable, V any] interface {
+	get(key K) (*valuePair[V], bool)
+	set(key K, vp *valuePair[V])
+	delete(key K)
+	clear()
+	entry(e *list.Element) (K, *valuePair[V])
 }
 
 // KeyValue represents a map elements as a key-value pair.
@@ -24,31 +35,66 @@ type KeyValue[K comparable, V any] struct {
 	Value V
 }
 
+// IndexedKeyValue represents a map element as a key-value pair along with
+// its position in the insertion order.
+type IndexedKeyValue[K comparable, V any] struct {
+	Index int
+	Key   K
+	Value V
+}
+
 // Map represents an ordered map which is an extension of hashmap.
 // Unlike hashmap, the ordered map maintains the insertion order
 // i.e. the order in which the keys and their mapped values are
 // inserted in the map. The insertion order is not changed if a key
 // which already exists in the map is re-inserted.
+//
+// A Map is not safe for concurrent use. Any number of goroutines may read
+// it concurrently through the methods of ReadOnlyMap as long as no goroutine
+// modifies it. Otherwise, the access must be synchronized by the caller, or
+// ShardedMap can be used instead. WithRaceCheck helps to catch accidental
+// concurrent use.
 type Map[K comparable, V any] struct {
 	mp    map[K]*valuePair[V]
+	index keyIndex[K, V] // replaces mp if set by WithRadixTree
 	items *list.List
+	cfg   config
+	stats *stats
+	guard *raceGuard
+	mods  uint64
+
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
 }
 
-// NewMap initializes an ordered map.
-func NewMap[K comparable, V any]() *Map[K, V] {
-	return &Map[K, V]{
-		mp:    make(map[K]*valuePair[V]),
-		items: list.New(),
-	}
+// NewMap initializes an ordered map configured by the given options.
+func NewMap[K comparable, V any](opts ...Option) *Map[K, V] {
+	return NewMapWithCapacity[K, V](0, opts...)
 }
 
 // NewMapWithCapacity initializes an ordered map with the given
-// initial capacity.
-func NewMapWithCapacity[K comparable, V any](capacity int) *Map[K, V] {
-	return &Map[K, V]{
-		mp:    make(map[K]*valuePair[V], capacity),
+// initial capacity configured by the given options.
+func NewMapWithCapacity[K comparable, V any](capacity int, opts ...Option) *Map[K, V] {
+	om := &Map[K, V]{
 		items: list.New(),
+		cfg:   newConfig(opts),
+	}
+	if om.cfg.radixTree {
+		index, ok := any(newTrieIndex[V](om.items)).(keyIndex[K, V])
+		if !ok {
+			panic("ordered: WithRadixTree requires string keys")
+		}
+		om.index = index
+	} else {
+		om.mp = make(map[K]*valuePair[V], capacity)
 	}
+	if om.cfg.stats {
+		om.stats = &stats{}
+	}
+	if om.cfg.raceCheck {
+		om.guard = &raceGuard{}
+	}
+	return om
 }
 
 // NewMapWithKVs initializes an ordered map and inserts the given key-value pair
@@ -61,21 +107,192 @@ func NewMapWithKVs[K comparable, V any](kvs ...KeyValue[K, V]) *Map[K, V] {
 	return om
 }
 
+// NewMapFromKVs initializes an ordered map configured by the given options
+// from the given key-value pairs in a single pass. The map is pre-sized and
+// the entries are allocated in chunks instead of one by one through Put,
+// which makes it considerably faster than NewMapWithKVs for bulk loading.
+// A removed entry stays allocated until all the entries of its chunk, which
+// holds at most 64 entries, are removed. If a key is repeated, the last
+// value wins and the key keeps the position of its first occurrence. The
+// slice is not retained by the map.
+func NewMapFromKVs[K comparable, V any](kvs []KeyValue[K, V], opts ...Option) *Map[K, V] {
+	om := NewMapWithCapacity[K, V](len(kvs), opts...)
+	om.adoptAll(len(kvs), func(i int) (K, V) {
+		return kvs[i].Key, kvs[i].Value
+	})
+	return om
+}
+
+// NewMapFromKeysValues initializes an ordered map configured by the given
+// options from the parallel slices of keys and values in a single pass like
+// NewMapFromKVs. The i-th key is mapped to the i-th value. It panics if the
+// slices have different lengths. The slices are not retained by the map.
+func NewMapFromKeysValues[K comparable, V any](keys []K, values []V, opts ...Option) *Map[K, V] {
+	if len(keys) != len(values) {
+		panic("ordered: keys and values have different lengths")
+	}
+	om := NewMapWithCapacity[K, V](len(keys), opts...)
+	om.adoptAll(len(keys), func(i int) (K, V) {
+		return keys[i], values[i]
+	})
+	return om
+}
+
+// pairChunk is the number of pairs allocated at once by adoptAll. It bounds
+// the memory retained by the surviving entries of a chunk.
+const pairChunk = 64
+
+// adoptAll inserts the n key-value pairs returned by kv using the pairs
+// allocated in chunks.
+func (o *Map[K, V]) adoptAll(n int, kv func(int) (K, V)) {
+	var vps []valuePair[V]
+	for i := 0; i < n; i++ {
+		if len(vps) == 0 {
+			size := n - i
+			if size > pairChunk {
+				size = pairChunk
+			}
+			vps = make([]valuePair[V], size)
+		}
+		key, value := kv(i)
+		if o.adopt(&vps[0], key, value) {
+			vps = vps[1:]
+		}
+	}
+}
+
+// adopt inserts a key and its mapped value like Put, but a new key uses the
+// pre-allocated pair vp. It returns whether vp is used.
+func (o *Map[K, V]) adopt(vp *valuePair[V], key K, value V) bool {
+	old, ok := o.lookup(key)
+	if ok {
+		vp = old
+	} else {
+		if o.full() {
+			return false
+		}
+		if o.cfg.internKeys {
+			key = internValue(key)
+		}
+		vp.elem = o.items.PushBack(key)
+		o.store(key, vp)
+	}
+	o.stats.put(!ok)
+	o.setValue(vp, value)
+	return !ok
+}
+
+// lookup returns the pair of the given key and a bool indicating whether
+// the key exists or not.
+func (o *Map[K, V]) lookup(key K) (*valuePair[V], bool) {
+	if o.index != nil {
+		return o.index.get(key)
+	}
+	vp, ok := o.mp[key]
+	return vp, ok
+}
+
+// store maps the key to its pair whose element is already in the list.
+func (o *Map[K, V]) store(key K, vp *valuePair[V]) {
+	if o.index != nil {
+		o.index.set(key, vp)
+		return
+	}
+	o.mp[key] = vp
+}
+
+// unstore removes the mapping of the key.
+func (o *Map[K, V]) unstore(key K) {
+	if o.index != nil {
+		o.index.delete(key)
+		return
+	}
+	delete(o.mp, key)
+}
+
+// entry returns the key and the pair of the given list element.
+func (o *Map[K, V]) entry(e *list.Element) (K, *valuePair[V]) {
+	if o.index != nil {
+		return o.index.entry(e)
+	}
+	key := e.Value.(K)
+	return key, o.mp[key]
+}
+
+// keyOf returns the key of the given list element.
+func (o *Map[K, V]) keyOf(e *list.Element) K {
+	if o.index != nil {
+		key, _ := o.index.entry(e)
+		return key
+	}
+	return e.Value.(K)
+}
+
+// ErrFull is returned when a new key is put in a map or set which already
+// holds the maximum number of entries set by WithMaxEntries.
+var ErrFull = errors.New("map is full")
+
 // Put inserts a key and its mapped value in the map. If the key already exists, the
-// mapped value is replaced by the new value.
+// mapped value is replaced by the new value. If the map is created WithMaxEntries
+// and is full, a new key is ignored. Use PutE to detect it.
 func (o *Map[K, V]) Put(key K, value V) {
-	if _, ok := o.mp[key]; !ok {
+	o.guard.enter()
+	defer o.guard.exit()
+	vp, ok := o.lookup(key)
+	if !ok {
+		if o.full() {
+			return
+		}
+		if o.cfg.internKeys {
+			key = internValue(key)
+		}
 		e := o.items.PushBack(key)
-		o.mp[key] = &valuePair[V]{elem: e, value: value}
-	} else {
-		o.mp[key].value = value
+		vp = &valuePair[V]{elem: e}
+		o.store(key, vp)
+		o.guard.modified()
+	}
+	o.stats.put(!ok)
+	o.setValue(vp, value)
+}
+
+// setValue sets the mapped value of a key in its pair.
+func (o *Map[K, V]) setValue(vp *valuePair[V], value V) {
+	if o.cfg.internValues {
+		value = internValue(value)
+	}
+	o.mods++
+	vp.value = value
+	if o.cfg.timestamps {
+		now := o.cfg.now()
+		if vp.times == nil {
+			vp.times = &entryTimes{inserted: now}
+		}
+		vp.times.updated = now
 	}
 }
 
+// PutE inserts a key and its mapped value in the map like Put. It returns ErrFull
+// if the key is new and the map already holds the maximum number of entries set
+// by WithMaxEntries.
+func (o *Map[K, V]) PutE(key K, value V) error {
+	if _, ok := o.lookup(key); !ok && o.full() {
+		return ErrFull
+	}
+	o.Put(key, value)
+	return nil
+}
+
+// full checks whether the map holds the maximum number of entries or not.
+func (o *Map[K, V]) full() bool {
+	return o.cfg.maxEntries > 0 && o.items.Len() >= o.cfg.maxEntries
+}
+
 // Get returns the mapped value for the given key and a bool indicating
 // whether the key exists or not.
 func (o *Map[K, V]) Get(key K) (V, bool) {
-	if val, ok := o.mp[key]; ok {
+	val, ok := o.lookup(key)
+	o.stats.get(ok)
+	if ok {
 		return val.value, true
 	}
 	var dummy V
@@ -85,7 +302,9 @@ func (o *Map[K, V]) Get(key K) (V, bool) {
 // GetOrDefault returns the mapped value for the given key if it exists.
 // Otherwise, it returns the default value.
 func (o *Map[K, V]) GetOrDefault(key K, defaultValue V) V {
-	if val, ok := o.mp[key]; ok {
+	val, ok := o.lookup(key)
+	o.stats.get(ok)
+	if ok {
 		return val.value
 	}
 	return defaultValue
@@ -93,17 +312,26 @@ func (o *Map[K, V]) GetOrDefault(key K, defaultValue V) V {
 
 // ContainsKey checks if the map contains a mapping for the given key.
 func (o *Map[K, V]) ContainsKey(key K) bool {
-	_, ok := o.mp[key]
+	_, ok := o.lookup(key)
+	o.stats.get(ok)
 	return ok
 }
 
 // Remove removes the key with its mapped value from the map and returns
 // the value if the key exists.
 func (o *Map[K, V]) Remove(key K) V {
-	if vp, ok := o.mp[key]; ok {
+	o.guard.enter()
+	defer o.guard.exit()
+	if vp, ok := o.lookup(key); ok {
 		value := vp.value
 		o.items.Remove(vp.elem)
-		delete(o.mp, key)
+		o.unstore(key)
+		// the pair may belong to a slab allocated by a bulk constructor,
+		// so drop its references to let the value be garbage collected
+		*vp = valuePair[V]{}
+		o.stats.remove(1)
+		o.guard.modified()
+		o.mods++
 		return value
 	}
 	var dummy V
@@ -115,30 +343,39 @@ func (o *Map[K, V]) Len() int {
 	return o.items.Len()
 }
 
+// ModCount returns the number of modifications of the map, i.e. insertions,
+// updates and removals. It only grows, so it can be compared with an older
+// result to cheaply detect whether the map has changed since then.
+func (o *Map[K, V]) ModCount() uint64 {
+	return o.mods
+}
+
 // Keys returns all the keys from the map according to their insertion order.
 // The first element of the slice is the oldest key in the map.
 func (o *Map[K, V]) Keys() []K {
+	mods := o.guard.modCount()
 	keys := make([]K, o.items.Len())
 	idx := 0
 	for e := o.items.Front(); e != nil; e = e.Next() {
-		keys[idx] = e.Value.(K)
+		keys[idx] = o.keyOf(e)
 		idx++
 	}
+	o.guard.check(mods)
 	return keys
 }
 
 // Values returns all the values from the map according to their insertion order.
 // The first element of the slice is the oldest value in the map.
 func (o *Map[K, V]) Values() []V {
+	mods := o.guard.modCount()
 	values := make([]V, o.items.Len())
 	idx := 0
 	for e := o.items.Front(); e != nil; e = e.Next() {
-		key := e.Value.(K)
-		if vp, ok := o.mp[key]; ok {
-			values[idx] = vp.value
-			idx++
-		}
+		_, vp := o.entry(e)
+		values[idx] = vp.value
+		idx++
 	}
+	o.guard.check(mods)
 	return values
 }
 
@@ -146,32 +383,60 @@ func (o *Map[K, V]) Values() []V {
 // insertion order. The first element of the slice is the oldest key and value
 // in the map.
 func (o *Map[K, V]) KeyValues() []KeyValue[K, V] {
+	mods := o.guard.modCount()
 	kvs := make([]KeyValue[K, V], o.items.Len())
 	idx := 0
 	for e := o.items.Front(); e != nil; e = e.Next() {
-		key := e.Value.(K)
-		if vp, ok := o.mp[key]; ok {
-			kvs[idx] = KeyValue[K, V]{Key: key, Value: vp.value}
-			idx++
-		}
+		key, vp := o.entry(e)
+		kvs[idx] = KeyValue[K, V]{Key: key, Value: vp.value}
+		idx++
 	}
+	o.guard.check(mods)
 	return kvs
 }
 
+// EntriesWithIndex returns all the keys and values from the map along with their
+// positions according to their insertion order. The oldest key and value in the
+// map have the index 0.
+func (o *Map[K, V]) EntriesWithIndex() []IndexedKeyValue[K, V] {
+	mods := o.guard.modCount()
+	entries := make([]IndexedKeyValue[K, V], o.items.Len())
+	idx := 0
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		key, vp := o.entry(e)
+		entries[idx] = IndexedKeyValue[K, V]{Index: idx, Key: key, Value: vp.value}
+		idx++
+	}
+	o.guard.check(mods)
+	return entries
+}
+
 // ForEach invokes the given function f for each element of the map.
 func (o *Map[K, V]) ForEach(f func(K, V)) {
+	mods := o.guard.modCount()
 	for _, kv := range o.KeyValues() {
 		f(kv.Key, kv.Value)
+		o.guard.check(mods)
 	}
 }
 
 // IsEmpty checks whether the map is empty or not.
 func (o *Map[K, V]) IsEmpty() bool {
-	return len(o.mp) == 0
+	return o.items.Len() == 0
 }
 
 // Clear removes all the keys and their mapped values from the map.
 func (o *Map[K, V]) Clear() {
+	o.guard.enter()
+	defer o.guard.exit()
+	o.guard.modified()
+	if o.items.Len() > 0 {
+		o.mods++
+	}
+	o.stats.remove(o.items.Len())
+	if o.index != nil {
+		o.index.clear()
+	}
 	for k := range o.mp {
 		delete(o.mp, k)
 	}
@@ -184,21 +449,11 @@ func (o *Map[K, V]) Clear() {
 
 // String returns the string representation of the map.
 func (o *Map[K, V]) String() string {
-	var sb strings.Builder
-	sb.WriteString("map{")
-	for idx, kv := range o.KeyValues() {
-		if idx > 0 {
-			sb.WriteByte(' ')
-		}
-		sb.WriteString(fmt.Sprint(kv.Key))
-		sb.WriteByte(':')
-		sb.WriteString(fmt.Sprint(kv.Value))
-	}
-	sb.WriteByte('}')
-	return sb.String()
+	return o.FormatString(DefaultFormatOptions())
 }
 
-// MarshalJSON implements json.Marshaler interface.
+// MarshalJSON implements json.Marshaler interface. The string keys are
+// transformed by the function set by WithKeyTransform.
 func (o Map[K, V]) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	buf.WriteByte('{')
@@ -206,24 +461,15 @@ func (o Map[K, V]) MarshalJSON() ([]byte, error) {
 		if idx > 0 {
 			buf.WriteByte(',')
 		}
-		// key type must either be a string, an integer type, or implement encoding.TextMarshaler
-		switch any(kv.Key).(type) {
-		case string, encoding.TextMarshaler:
-			keyBytes, err := json.Marshal(kv.Key)
-			if err != nil {
-				return nil, err
-			}
-			buf.Write(keyBytes)
-		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-			var keyBytes bytes.Buffer
-			b, _ := json.Marshal(kv.Key) // marshalling int/uint does not generate error
-			keyBytes.WriteByte('"')
-			keyBytes.Write(b)
-			keyBytes.WriteByte('"')
-			buf.Write(keyBytes.Bytes())
-		default:
-			return nil, errors.New("invalid key type")
+		key := any(kv.Key)
+		if str, ok := key.(string); ok && o.cfg.keyTransform != nil {
+			key = o.cfg.keyTransform(str)
+		}
+		keyBytes, err := marshalKey(key)
+		if err != nil {
+			return nil, err
 		}
+		buf.Write(keyBytes)
 
 		buf.WriteByte(':')
 		valBytes, err := json.Marshal(kv.Value)
@@ -236,73 +482,129 @@ func (o Map[K, V]) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// UnmarshalJSON implements json.Unmarshaler interface.
+// UnmarshalJSON implements json.Unmarshaler interface. It returns a DecodeLimitError
+// if the input exceeds a limit set by WithDecodeMaxDepth or WithDecodeMaxEntries.
 func (o *Map[K, V]) UnmarshalJSON(b []byte) error {
-	if o.items == nil || o.mp == nil {
+	if o.items == nil {
 		o.mp = make(map[K]*valuePair[V])
 		o.items = list.New()
 	}
+	if err := o.cfg.checkLimits(b); err != nil {
+		return err
+	}
 	return jsonparser.ObjectEach(b, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
 		var k K
-		// key type must either be a string, an integer type, or implement encoding.TextMarshaler
-		switch any(k).(type) {
-		case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, encoding.TextMarshaler:
-			if err := json.Unmarshal([]byte(fmt.Sprintf("\"%s\"", string(key))), &k); err != nil {
-				return err
-			}
-		default:
-			return errors.New("invalid key type")
+		if err := unmarshalKey(key, any(k), &k); err != nil {
+			return err
 		}
 		var v V
-		var valBytes []byte
-		if dataType == jsonparser.String {
-			valBytes = []byte(fmt.Sprintf("\"%s\"", string(value)))
-		} else {
-			valBytes = value
-		}
-		if err := json.Unmarshal(valBytes, &v); err != nil {
+		if err := json.Unmarshal(rawValue(value, dataType), &v); err != nil {
 			return err
 		}
-		o.Put(k, v)
-		return nil
+		return o.PutE(k, v)
 	})
 }
 
-// GobEncode implements gob.GobEncoder interface.
+// marshalKey marshals a map key to a JSON object key.
+func marshalKey(key any) ([]byte, error) {
+	// key type must either be a string, an integer type, or implement encoding.TextMarshaler
+	switch key.(type) {
+	case string, encoding.TextMarshaler:
+		return json.Marshal(key)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		var keyBytes bytes.Buffer
+		b, _ := json.Marshal(key) // marshalling int/uint does not generate error
+		keyBytes.WriteByte('"')
+		keyBytes.Write(b)
+		keyBytes.WriteByte('"')
+		return keyBytes.Bytes(), nil
+	default:
+		return nil, errors.New("invalid key type")
+	}
+}
+
+// unmarshalKey unmarshals a JSON object key into ptr. The zero value of the
+// key type is used to check whether the key type is supported or not.
+func unmarshalKey(key []byte, zero any, ptr any) error {
+	// key type must either be a string, an integer type, or implement encoding.TextMarshaler
+	switch zero.(type) {
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, encoding.TextMarshaler:
+		return json.Unmarshal([]byte(fmt.Sprintf("\"%s\"", string(key))), ptr)
+	default:
+		return errors.New("invalid key type")
+	}
+}
+
+// rawValue returns the JSON representation of a value parsed by jsonparser
+// which strips the quotes of string values.
+func rawValue(value []byte, dataType jsonparser.ValueType) []byte {
+	if dataType == jsonparser.String {
+		return []byte(fmt.Sprintf("\"%s\"", string(value)))
+	}
+	return value
+}
+
+// GobEncode implements gob.GobEncoder interface. The keys and the values
+// are encoded by their codecs if set by SetKeyCodec and SetValueCodec.
 func (o Map[K, V]) GobEncode() ([]byte, error) {
 	var buf bytes.Buffer
-	enc := gob.NewEncoder(&buf)
-	enc.Encode(o.Len())
+	if err := o.encodeGob(gob.NewEncoder(&buf)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder interface. The keys and the values
+// are decoded by their codecs if set by SetKeyCodec and SetValueCodec.
+func (o *Map[K, V]) GobDecode(b []byte) error {
+	return o.decodeGob(gob.NewDecoder(bytes.NewBuffer(b)))
+}
+
+// encodeGob encodes the length of the map followed by the keys and the
+// values in the insertion order.
+func (o *Map[K, V]) encodeGob(enc *gob.Encoder) error {
+	if err := enc.Encode(o.Len()); err != nil {
+		return err
+	}
 	for _, kv := range o.KeyValues() {
-		if err := enc.Encode(kv.Key); err != nil {
-			return nil, err
+		if err := encodeWith(enc, o.keyCodec, kv.Key); err != nil {
+			return err
 		}
-		if err := enc.Encode(kv.Value); err != nil {
-			return nil, err
+		if err := encodeWith(enc, o.valueCodec, kv.Value); err != nil {
+			return err
 		}
 	}
-	return buf.Bytes(), nil
+	return nil
 }
 
-// GobDecode implements gob.GobDecoder interface.
-func (o *Map[K, V]) GobDecode(b []byte) error {
-	if o.items == nil || o.mp == nil {
+// decodeGob decodes the entries encoded by encodeGob into the map.
+func (o *Map[K, V]) decodeGob(dec *gob.Decoder) error {
+	if o.items == nil {
 		o.mp = make(map[K]*valuePair[V])
 		o.items = list.New()
 	}
-	dec := gob.NewDecoder(bytes.NewBuffer(b))
+	return o.decodeGobWith(dec, o.PutE)
+}
+
+// decodeGobWith decodes the entries encoded by encodeGob using the codecs
+// of the map and passes them to put.
+func (o *Map[K, V]) decodeGobWith(dec *gob.Decoder, put func(K, V) error) error {
 	len := 0
-	dec.Decode(&len)
+	if err := dec.Decode(&len); err != nil {
+		return err
+	}
 	for i := 0; i < len; i++ {
 		var k K
 		var v V
-		if err := dec.Decode(&k); err != nil {
+		if err := decodeWith(dec, o.keyCodec, &k); err != nil {
+			return err
+		}
+		if err := decodeWith(dec, o.valueCodec, &v); err != nil {
 			return err
 		}
-		if err := dec.Decode(&v); err != nil {
+		if err := put(k, v); err != nil {
 			return err
 		}
-		o.Put(k, v)
 	}
 	return nil
 }
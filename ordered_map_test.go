@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/nhAnik/ordered"
 	"github.com/stretchr/testify/assert"
@@ -67,6 +68,93 @@ func TestNewMapWithKVs(t *testing.T) {
 	assert.Equal(t, []int{11, 20, 23, 99}, om.Keys())
 }
 
+func TestNewMapFromKVs(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	kvs := []kv{{"foo", 1}, {"bar", 2}, {"foo", 3}, {"baz", 4}}
+	om := ordered.NewMapFromKVs(kvs)
+
+	assert.Equal(t, 3, om.Len())
+	assert.Equal(t, []kv{{"foo", 3}, {"bar", 2}, {"baz", 4}}, om.KeyValues())
+
+	// the map does not retain the slice
+	kvs[1].Value = 20
+	assert.Equal(t, 2, om.GetOrDefault("bar", 0))
+
+	om.Remove("bar")
+	om.Put("qux", 5)
+	om.Put("foo", 6)
+	assert.Equal(t, []kv{{"foo", 6}, {"baz", 4}, {"qux", 5}}, om.KeyValues())
+
+	assert.True(t, ordered.NewMapFromKVs[string, int](nil).IsEmpty())
+
+	t.Run("options", func(t *testing.T) {
+		om := ordered.NewMapFromKVs(kvs, ordered.WithMaxEntries(1, ordered.PolicyReject), ordered.WithTimestamps())
+		assert.Equal(t, []kv{{"foo", 3}}, om.KeyValues())
+		_, ok := om.InsertedAt("foo")
+		assert.True(t, ok)
+	})
+
+	t.Run("many entries", func(t *testing.T) {
+		kvs := make([]kv, 200)
+		for i := range kvs {
+			kvs[i] = kv{strconv.Itoa(i % 150), i}
+		}
+		om := ordered.NewMapFromKVs(kvs)
+		assert.Equal(t, 150, om.Len())
+		assert.Equal(t, 199, om.GetOrDefault("49", 0))
+		assert.Equal(t, 149, om.GetOrDefault("149", 0))
+	})
+}
+
+func TestNewMapFromKeysValues(t *testing.T) {
+	om := ordered.NewMapFromKeysValues([]int{3, 1, 2, 1}, []string{"c", "a", "b", "d"})
+
+	assert.Equal(t, []int{3, 1, 2}, om.Keys())
+	assert.Equal(t, []string{"c", "d", "b"}, om.Values())
+
+	om = ordered.NewMapFromKeysValues([]int{1, 2}, []string{"a", "b"}, ordered.WithStats())
+	assert.Equal(t, ordered.Stats{Len: 2, Puts: 2}, om.Stats())
+
+	assert.Panics(t, func() {
+		ordered.NewMapFromKeysValues([]int{1, 2}, []string{"a"})
+	})
+}
+
+func TestPutE(t *testing.T) {
+	om := ordered.NewMap[string, int](ordered.WithMaxEntries(2, ordered.PolicyReject))
+
+	assert.NoError(t, om.PutE("foo", 1))
+	assert.NoError(t, om.PutE("bar", 2))
+	assert.ErrorIs(t, om.PutE("baz", 3), ordered.ErrFull)
+	assert.False(t, om.ContainsKey("baz"))
+
+	// existing keys can still be updated
+	assert.NoError(t, om.PutE("foo", 10))
+	assert.Equal(t, []int{10, 2}, om.Values())
+
+	// Put ignores new keys silently
+	om.Put("baz", 3)
+	assert.Equal(t, []string{"foo", "bar"}, om.Keys())
+
+	om.Remove("bar")
+	assert.NoError(t, om.PutE("baz", 3))
+	assert.Equal(t, []string{"foo", "baz"}, om.Keys())
+
+	t.Run("decoding", func(t *testing.T) {
+		om := ordered.NewMap[string, int](ordered.WithMaxEntries(2, ordered.PolicyReject))
+		err := json.Unmarshal([]byte(`{"a":1,"b":2,"c":3}`), om)
+		assert.ErrorIs(t, err, ordered.ErrFull)
+
+		var buf bytes.Buffer
+		src := ordered.NewMapWithKVs(ordered.KeyValue[string, int]{"a", 1}, ordered.KeyValue[string, int]{"b", 2})
+		assert.NoError(t, gob.NewEncoder(&buf).Encode(src))
+
+		om = ordered.NewMap[string, int](ordered.WithMaxEntries(1, ordered.PolicyReject))
+		err = gob.NewDecoder(&buf).Decode(om)
+		assert.ErrorIs(t, err, ordered.ErrFull)
+	})
+}
+
 func TestGet(t *testing.T) {
 
 	t.Run("empty map get", func(t *testing.T) {
@@ -294,6 +382,23 @@ func TestKeyValues(t *testing.T) {
 	assert.Equal(t, []kv{}, om.KeyValues())
 }
 
+func TestEntriesWithIndex(t *testing.T) {
+	om := ordered.NewMap[string, int]()
+	type ikv = ordered.IndexedKeyValue[string, int]
+
+	om.Put("foo", 10)
+	om.Put("abd", 20)
+	om.Put("abc", 30)
+	assert.Equal(t, []ikv{{0, "foo", 10}, {1, "abd", 20}, {2, "abc", 30}}, om.EntriesWithIndex())
+
+	om.Remove("foo")
+	om.Put("abd", 25)
+	assert.Equal(t, []ikv{{0, "abd", 25}, {1, "abc", 30}}, om.EntriesWithIndex())
+
+	om.Clear()
+	assert.Equal(t, []ikv{}, om.EntriesWithIndex())
+}
+
 func TestForEach(t *testing.T) {
 	om := ordered.NewMap[string, int]()
 	om.Put("foo", 10)
@@ -331,6 +436,36 @@ func TestClear(t *testing.T) {
 	assert.True(t, om.IsEmpty())
 }
 
+func TestModCount(t *testing.T) {
+	om := ordered.NewMap[string, int]()
+	assert.Equal(t, uint64(0), om.ModCount())
+
+	om.Put("foo", 1)
+	om.Put("bar", 2)
+	assert.Equal(t, uint64(2), om.ModCount())
+
+	om.Put("foo", 10)
+	assert.Equal(t, uint64(3), om.ModCount())
+
+	om.Get("foo")
+	om.Keys()
+	om.Remove("baz")
+	assert.Equal(t, uint64(3), om.ModCount())
+
+	om.Remove("foo")
+	assert.Equal(t, uint64(4), om.ModCount())
+
+	om.Clear()
+	om.Clear()
+	assert.Equal(t, uint64(5), om.ModCount())
+
+	vm := ordered.NewVersionedMap[string, int]()
+	vm.Put("foo", 1)
+	vm.Remove("foo")
+	vm.Undo()
+	assert.Equal(t, uint64(3), vm.ModCount())
+}
+
 func TestString(t *testing.T) {
 	t.Run("int bool map", func(t *testing.T) {
 		type kv = ordered.KeyValue[int, bool]
@@ -355,6 +490,37 @@ func TestString(t *testing.T) {
 }
 
 func TestMarshalJSON(t *testing.T) {
+	t.Run("key transform", func(t *testing.T) {
+		snake := func(s string) string {
+			var sb strings.Builder
+			for i, r := range s {
+				if unicode.IsUpper(r) {
+					if i > 0 {
+						sb.WriteByte('_')
+					}
+					r = unicode.ToLower(r)
+				}
+				sb.WriteRune(r)
+			}
+			return sb.String()
+		}
+		om := ordered.NewMap[string, int](ordered.WithKeyTransform(snake))
+		om.Put("UserName", 1)
+		om.Put("createdAt", 2)
+		om.Put("name", 3)
+
+		b, err := json.Marshal(om)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"user_name":1,"created_at":2,"name":3}`, string(b))
+		assert.Equal(t, []string{"UserName", "createdAt", "name"}, om.Keys())
+
+		im := ordered.NewMap[int, int](ordered.WithKeyTransform(strings.ToUpper))
+		im.Put(1, 1)
+		b, err = json.Marshal(im)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"1":1}`, string(b))
+	})
+
 	t.Run("string any map", func(t *testing.T) {
 		type dummy struct{ Elem string }
 		type kv = ordered.KeyValue[string, any]
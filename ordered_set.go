@@ -2,11 +2,9 @@ package ordered
 
 import (
 	"bytes"
+	"container/list"
 	"encoding/gob"
-	"encoding/json"
 	"errors"
-	"fmt"
-	"strings"
 
 	"github.com/buger/jsonparser"
 )
@@ -17,21 +15,22 @@ var dummy = struct{}{}
 // insertion order intact. The insertion order is not changed if a element
 // which already exists in the set is re-inserted.
 type Set[T comparable] struct {
-	mp *Map[T, struct{}]
+	mp  *Map[T, struct{}]
+	enc ElementEncoding
 }
 
-// NewSet initializes an ordered set.
-func NewSet[T comparable]() *Set[T] {
+// NewSet initializes an ordered set configured by the given options.
+func NewSet[T comparable](opts ...Option) *Set[T] {
 	return &Set[T]{
-		mp: NewMap[T, struct{}](),
+		mp: NewMap[T, struct{}](opts...),
 	}
 }
 
 // NewSetWithCapacity initializes an ordered set with the given
-// initial capacity..
-func NewSetWithCapacity[T comparable](capacity int) *Set[T] {
+// initial capacity configured by the given options.
+func NewSetWithCapacity[T comparable](capacity int, opts ...Option) *Set[T] {
 	return &Set[T]{
-		mp: NewMapWithCapacity[T, struct{}](capacity),
+		mp: NewMapWithCapacity[T, struct{}](capacity, opts...),
 	}
 }
 
@@ -45,11 +44,19 @@ func NewSetWithElems[T comparable](elems ...T) *Set[T] {
 	return s
 }
 
-// Add inserts a new element in the set.
+// Add inserts a new element in the set. If the set is created WithMaxEntries
+// and is full, a new element is ignored. Use AddE to detect it.
 func (s *Set[T]) Add(elem T) {
 	s.mp.Put(elem, dummy)
 }
 
+// AddE inserts a new element in the set like Add. It returns ErrFull if the
+// element is new and the set already holds the maximum number of elements
+// set by WithMaxEntries.
+func (s *Set[T]) AddE(elem T) error {
+	return s.mp.PutE(elem, dummy)
+}
+
 // Contains checks if the set contains the given element or not.
 func (s *Set[T]) Contains(elem T) bool {
 	return s.mp.ContainsKey(elem)
@@ -59,7 +66,9 @@ func (s *Set[T]) Contains(elem T) bool {
 // already there in the set. The returned boolean value indicates
 // whether the element is removed or not.
 func (s *Set[T]) Remove(elem T) bool {
-	if !s.Contains(elem) {
+	// the map is looked up directly, so that a removal is not counted as
+	// a lookup in the stats
+	if _, ok := s.mp.lookup(elem); !ok {
 		return false
 	}
 	s.mp.Remove(elem)
@@ -78,10 +87,20 @@ func (s *Set[T]) Elements() []T {
 	return s.mp.Keys()
 }
 
+// ModCount returns the number of modifications of the set, i.e. additions
+// and removals. Adding an existing element counts as well. It only grows, so
+// it can be compared with an older result to cheaply detect whether the set
+// may have changed since then.
+func (s *Set[T]) ModCount() uint64 {
+	return s.mp.ModCount()
+}
+
 // ForEach invokes the given function f for each element of the set.
 func (o *Set[T]) ForEach(f func(T)) {
+	mods := o.mp.guard.modCount()
 	for _, e := range o.Elements() {
 		f(e)
+		o.mp.guard.check(mods)
 	}
 }
 
@@ -95,21 +114,78 @@ func (s *Set[T]) Clear() {
 	s.mp.Clear()
 }
 
-// String returns the string representation of the set.
-func (s *Set[T]) String() string {
-	var sb strings.Builder
-	sb.WriteString("set{")
-	for idx, elem := range s.Elements() {
-		if idx > 0 {
-			sb.WriteByte(' ')
+// Union returns a new set containing the elements of both the sets. The
+// elements of s come first followed by the elements only in other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	return NewSetWithCapacity[T](s.Len() + other.Len()).UnionWith(s).UnionWith(other)
+}
+
+// Intersect returns a new set containing the elements of s which are also
+// in other. The order of the elements in s is preserved.
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	is := NewSet[T]()
+	for e := s.mp.items.Front(); e != nil; e = e.Next() {
+		if elem := s.mp.keyOf(e); other.Contains(elem) {
+			is.Add(elem)
+		}
+	}
+	return is
+}
+
+// Difference returns a new set containing the elements of s which are not
+// in other. The order of the elements in s is preserved.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	ds := NewSet[T]()
+	for e := s.mp.items.Front(); e != nil; e = e.Next() {
+		if elem := s.mp.keyOf(e); !other.Contains(elem) {
+			ds.Add(elem)
 		}
-		sb.WriteString(fmt.Sprint(elem))
 	}
-	sb.WriteByte('}')
-	return sb.String()
+	return ds
+}
+
+// UnionWith adds the elements of other to s in place and returns s for
+// chaining.
+func (s *Set[T]) UnionWith(other *Set[T]) *Set[T] {
+	for e := other.mp.items.Front(); e != nil; e = e.Next() {
+		s.Add(other.mp.keyOf(e))
+	}
+	return s
+}
+
+// IntersectWith removes the elements of s which are not in other in place
+// and returns s for chaining.
+func (s *Set[T]) IntersectWith(other *Set[T]) *Set[T] {
+	var next *list.Element
+	for e := s.mp.items.Front(); e != nil; e = next {
+		next = e.Next()
+		if elem := s.mp.keyOf(e); !other.Contains(elem) {
+			s.mp.Remove(elem)
+		}
+	}
+	return s
+}
+
+// DifferenceWith removes the elements of other from s in place and returns
+// s for chaining.
+func (s *Set[T]) DifferenceWith(other *Set[T]) *Set[T] {
+	if s == other {
+		s.Clear()
+		return s
+	}
+	for e := other.mp.items.Front(); e != nil; e = e.Next() {
+		s.mp.Remove(other.mp.keyOf(e))
+	}
+	return s
 }
 
-// MarshalJSON implements json.Marshaler interface.
+// String returns the string representation of the set.
+func (s *Set[T]) String() string {
+	return s.FormatString(DefaultFormatOptions())
+}
+
+// MarshalJSON implements json.Marshaler interface. The elements are represented
+// according to the encoding set by SetElementEncoding.
 func (s Set[T]) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	buf.WriteByte('[')
@@ -117,7 +193,7 @@ func (s Set[T]) MarshalJSON() ([]byte, error) {
 		if idx > 0 {
 			buf.WriteByte(',')
 		}
-		bytes, err := json.Marshal(elem)
+		bytes, err := marshalElem(elem, s.enc)
 		if err != nil {
 			return nil, err
 		}
@@ -127,55 +203,108 @@ func (s Set[T]) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// UnmarshalJSON implements json.Unmarshaler interface.
+// UnmarshalJSON implements json.Unmarshaler interface. The elements are expected
+// to be represented according to the encoding set by SetElementEncoding. It returns
+// a DecodeLimitError if the input exceeds a limit set by WithDecodeMaxDepth or
+// WithDecodeMaxEntries.
 func (s *Set[T]) UnmarshalJSON(b []byte) error {
 	if s.mp == nil {
 		s.mp = NewMap[T, struct{}]()
 	}
+	if err := s.mp.cfg.checkLimits(b); err != nil {
+		return err
+	}
 	unmarshalErrExists := false
+	var abortErr error
 	_, err := jsonparser.ArrayEach(b, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
-		var elem T
-		var elemBytes []byte
-		if dataType == jsonparser.String {
-			elemBytes = []byte(fmt.Sprintf("\"%s\"", string(value)))
-		} else {
-			elemBytes = value
+		if abortErr != nil {
+			return
 		}
-		if err := json.Unmarshal(elemBytes, &elem); err != nil {
+		var elem T
+		if err := unmarshalElem(rawValue(value, dataType), &elem, s.enc); err != nil {
 			unmarshalErrExists = true
 			return
 		}
-		s.Add(elem)
+		abortErr = s.AddE(elem)
 	})
 	if err != nil {
 		return err
 	}
+	if abortErr != nil {
+		return abortErr
+	}
 	if unmarshalErrExists {
 		return errors.New("unmarshalling error")
 	}
 	return nil
 }
 
-// GobEncode implements gob.GobEncoder interface.
+// GobEncode implements gob.GobEncoder interface. The elements are encoded
+// by the codec if set by SetCodec.
 func (s Set[T]) GobEncode() ([]byte, error) {
 	var buf bytes.Buffer
-	if err := gob.NewEncoder(&buf).Encode(s.Elements()); err != nil {
+	if err := s.encodeGob(gob.NewEncoder(&buf)); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
-// GobDecode implements gob.GobDecoder interface.
+// GobDecode implements gob.GobDecoder interface. The elements are decoded
+// by the codec if set by SetCodec.
 func (s *Set[T]) GobDecode(b []byte) error {
+	return s.decodeGob(gob.NewDecoder(bytes.NewBuffer(b)))
+}
+
+// encodeGob encodes the elements as a single slice in the insertion order.
+func (s *Set[T]) encodeGob(enc *gob.Encoder) error {
+	c := s.codec()
+	if c == nil {
+		return enc.Encode(s.Elements())
+	}
+	elems := make([][]byte, 0, s.Len())
+	for _, e := range s.Elements() {
+		b, err := c.Encode(e)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, b)
+	}
+	return enc.Encode(elems)
+}
+
+// decodeGob decodes the elements encoded by encodeGob into the set.
+func (s *Set[T]) decodeGob(dec *gob.Decoder) error {
 	if s.mp == nil {
 		s.mp = NewMap[T, struct{}]()
 	}
 	var elems []T
-	if err := gob.NewDecoder(bytes.NewBuffer(b)).Decode(&elems); err != nil {
+	if c := s.codec(); c != nil {
+		var raw [][]byte
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		elems = make([]T, len(raw))
+		for i, r := range raw {
+			e, err := c.Decode(r)
+			if err != nil {
+				return err
+			}
+			elems[i] = e
+		}
+	} else if err := dec.Decode(&elems); err != nil {
 		return err
 	}
 	for _, e := range elems {
-		s.Add(e)
+		if err := s.AddE(e); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+func (s Set[T]) codec() Codec[T] {
+	if s.mp == nil {
+		return nil
+	}
+	return s.mp.keyCodec
+}
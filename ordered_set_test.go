@@ -39,6 +39,31 @@ func TestAdd(t *testing.T) {
 	assert.Equal(t, []string{"foo", "bar"}, s.Elements())
 }
 
+func TestAddE(t *testing.T) {
+	s := ordered.NewSet[string](ordered.WithMaxEntries(2, ordered.PolicyReject))
+
+	assert.NoError(t, s.AddE("foo"))
+	assert.NoError(t, s.AddE("bar"))
+	assert.NoError(t, s.AddE("foo"))
+	assert.ErrorIs(t, s.AddE("baz"), ordered.ErrFull)
+
+	s.Add("baz")
+	assert.Equal(t, []string{"foo", "bar"}, s.Elements())
+
+	t.Run("decoding", func(t *testing.T) {
+		s := ordered.NewSet[int](ordered.WithMaxEntries(2, ordered.PolicyReject))
+		err := json.Unmarshal([]byte(`[1,2,2,3]`), s)
+		assert.ErrorIs(t, err, ordered.ErrFull)
+
+		var buf bytes.Buffer
+		assert.NoError(t, gob.NewEncoder(&buf).Encode(ordered.NewSetWithElems[int](1, 2, 3)))
+
+		s = ordered.NewSet[int](ordered.WithMaxEntries(2, ordered.PolicyReject))
+		err = gob.NewDecoder(&buf).Decode(s)
+		assert.ErrorIs(t, err, ordered.ErrFull)
+	})
+}
+
 func TestContains(t *testing.T) {
 	s := ordered.NewSetWithElems[string]("foo", "bar", "foo", "baz")
 
@@ -115,6 +140,66 @@ func TestSetClear(t *testing.T) {
 	assert.True(t, s.IsEmpty())
 }
 
+func TestSetUnion(t *testing.T) {
+	s1 := ordered.NewSetWithElems[int](1, 2, 3)
+	s2 := ordered.NewSetWithElems[int](4, 2, 5)
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s1.Union(s2).Elements())
+	assert.Equal(t, []int{4, 2, 5, 1, 3}, s2.Union(s1).Elements())
+	assert.Equal(t, []int{1, 2, 3}, s1.Elements())
+}
+
+func TestSetIntersect(t *testing.T) {
+	s1 := ordered.NewSetWithElems[int](1, 2, 3, 4)
+	s2 := ordered.NewSetWithElems[int](4, 2, 5)
+
+	assert.Equal(t, []int{2, 4}, s1.Intersect(s2).Elements())
+	assert.Equal(t, []int{4, 2}, s2.Intersect(s1).Elements())
+	assert.Equal(t, []int{}, s1.Intersect(ordered.NewSet[int]()).Elements())
+}
+
+func TestSetDifference(t *testing.T) {
+	s1 := ordered.NewSetWithElems[int](1, 2, 3, 4)
+	s2 := ordered.NewSetWithElems[int](4, 2, 5)
+
+	assert.Equal(t, []int{1, 3}, s1.Difference(s2).Elements())
+	assert.Equal(t, []int{5}, s2.Difference(s1).Elements())
+	assert.Equal(t, []int{1, 2, 3, 4}, s1.Elements())
+}
+
+func TestSetInPlaceOperations(t *testing.T) {
+	t.Run("chaining", func(t *testing.T) {
+		s := ordered.NewSetWithElems[string]("a", "b", "c")
+		res := s.UnionWith(ordered.NewSetWithElems[string]("d", "a")).
+			IntersectWith(ordered.NewSetWithElems[string]("d", "c", "b")).
+			DifferenceWith(ordered.NewSetWithElems[string]("c"))
+
+		assert.Same(t, s, res)
+		assert.Equal(t, []string{"b", "d"}, s.Elements())
+	})
+
+	t.Run("fold many sets", func(t *testing.T) {
+		tags := []*ordered.Set[string]{
+			ordered.NewSetWithElems[string]("prod", "eu", "web"),
+			ordered.NewSetWithElems[string]("web", "prod", "db"),
+			ordered.NewSetWithElems[string]("prod", "web"),
+		}
+		common := ordered.NewSet[string]().UnionWith(tags[0])
+		for _, t := range tags[1:] {
+			common.IntersectWith(t)
+		}
+		assert.Equal(t, []string{"prod", "web"}, common.Elements())
+	})
+
+	t.Run("same set", func(t *testing.T) {
+		s := ordered.NewSetWithElems[int](1, 2, 3)
+
+		assert.Equal(t, []int{1, 2, 3}, s.UnionWith(s).Elements())
+		assert.Equal(t, []int{1, 2, 3}, s.IntersectWith(s).Elements())
+		assert.True(t, s.DifferenceWith(s).IsEmpty())
+	})
+}
+
 func TestSetString(t *testing.T) {
 	t.Run("set of string", func(t *testing.T) {
 		s := ordered.NewSetWithElems[string]("abc", "def", "abc", "xyz")
@@ -141,6 +226,20 @@ func TestSetString(t *testing.T) {
 	})
 }
 
+func TestSetModCount(t *testing.T) {
+	s := ordered.NewSet[int]()
+	s.Add(1)
+	s.Add(2)
+	before := s.ModCount()
+
+	s.Contains(1)
+	s.Remove(3)
+	assert.Equal(t, before, s.ModCount())
+
+	s.Remove(1)
+	assert.Greater(t, s.ModCount(), before)
+}
+
 func TestSetMarshalJSON(t *testing.T) {
 	t.Run("set of string", func(t *testing.T) {
 		s := ordered.NewSetWithElems[string]("abc", "def", "abc", "xyz")
@@ -0,0 +1,70 @@
+package ordered
+
+import "sync/atomic"
+
+// WithRaceCheck makes the map panic when it detects concurrent modification,
+// i.e. two goroutines modifying the map at the same time, or a key being
+// inserted or removed while the map is iterated by Keys, Values, KeyValues,
+// ForEach or an Iterator. Updating the value of an existing key during
+// iteration is allowed. The detection is not guaranteed, so it is meant for
+// debugging and tests.
+func WithRaceCheck() Option {
+	return func(c *config) {
+		c.raceCheck = true
+	}
+}
+
+// raceGuard detects concurrent modification of its owner. All the methods
+// are no-op on a nil receiver.
+type raceGuard struct {
+	// mods counts the insertions and removals of keys. It is the first
+	// field to keep it 64-bit aligned for the atomic operations on 32-bit
+	// platforms.
+	mods    uint64
+	writers int32
+}
+
+// enter marks the beginning of a modification.
+func (g *raceGuard) enter() {
+	if g == nil {
+		return
+	}
+	if atomic.AddInt32(&g.writers, 1) != 1 {
+		atomic.AddInt32(&g.writers, -1)
+		panic("ordered: concurrent map writes")
+	}
+}
+
+// exit marks the end of a modification.
+func (g *raceGuard) exit() {
+	if g == nil {
+		return
+	}
+	atomic.AddInt32(&g.writers, -1)
+}
+
+// modified records an insertion or removal of a key.
+func (g *raceGuard) modified() {
+	if g == nil {
+		return
+	}
+	atomic.AddUint64(&g.mods, 1)
+}
+
+// modCount returns the number of insertions and removals so far.
+func (g *raceGuard) modCount() uint64 {
+	if g == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&g.mods)
+}
+
+// check panics if a key is inserted or removed since modCount returned mods.
+func (g *raceGuard) check(mods uint64) {
+	if g == nil {
+		return
+	}
+	if atomic.LoadUint64(&g.mods) != mods {
+		panic("ordered: map modified during iteration")
+	}
+}
@@ -0,0 +1,92 @@
+package ordered_test
+
+import (
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRaceCheckForEach(t *testing.T) {
+	t.Run("insert", func(t *testing.T) {
+		om := ordered.NewMap[string, int](ordered.WithRaceCheck())
+		om.Put("a", 1)
+		om.Put("b", 2)
+		assert.PanicsWithValue(t, "ordered: map modified during iteration", func() {
+			om.ForEach(func(k string, v int) {
+				om.Put(k+k, v)
+			})
+		})
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		s := ordered.NewSet[int](ordered.WithRaceCheck())
+		s.Add(1)
+		s.Add(2)
+		assert.Panics(t, func() {
+			s.ForEach(func(e int) {
+				s.Remove(e)
+			})
+		})
+	})
+
+	t.Run("update value", func(t *testing.T) {
+		om := ordered.NewMap[string, int](ordered.WithRaceCheck())
+		om.Put("a", 1)
+		om.Put("b", 2)
+		assert.NotPanics(t, func() {
+			om.ForEach(func(k string, v int) {
+				om.Put(k, v*10)
+			})
+		})
+		assert.Equal(t, []int{10, 20}, om.Values())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		om := ordered.NewMap[string, int]()
+		om.Put("a", 1)
+		assert.NotPanics(t, func() {
+			om.ForEach(func(k string, v int) {
+				om.Remove(k)
+			})
+		})
+	})
+}
+
+func TestRaceCheckIterator(t *testing.T) {
+	om := ordered.NewMap[string, int](ordered.WithRaceCheck())
+	om.Put("a", 1)
+	om.Put("b", 2)
+
+	it := om.IterateFrom("a")
+	om.Put("b", 20)
+	assert.Equal(t, "a", it.Key())
+	it.Next()
+	assert.Equal(t, 20, it.Value())
+
+	om.Remove("a")
+	assert.PanicsWithValue(t, "ordered: map modified during iteration", func() { it.Prev() })
+	assert.Panics(t, func() { it.Key() })
+
+	it = om.IterateFrom("b")
+	assert.Equal(t, "b", it.Key())
+	om.Clear()
+	assert.Panics(t, func() { it.Next() })
+}
+
+func TestRaceCheckConcurrentWriters(t *testing.T) {
+	om := ordered.NewMap[int, int](ordered.WithRaceCheck())
+	om.Put(1, 1)
+
+	// f runs while UpdateValues is modifying the map, so a write from f
+	// overlaps with it like a write from another goroutine would
+	assert.PanicsWithValue(t, "ordered: concurrent map writes", func() {
+		om.UpdateValues(func(k, v int) int {
+			om.Put(k+1, v)
+			return v
+		})
+	})
+
+	// the guard is released after the panic
+	assert.NotPanics(t, func() { om.Put(2, 2) })
+}
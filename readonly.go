@@ -0,0 +1,37 @@
+package ordered
+
+// ReadOnlyMap is the read-only view of an ordered map. It lets APIs accept
+// or return an ordered map without exposing the methods which modify it.
+// Map, TrieMap and the types wrapping Map implement ReadOnlyMap.
+type ReadOnlyMap[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	GetOrDefault(key K, defaultValue V) V
+	ContainsKey(key K) bool
+	Len() int
+	IsEmpty() bool
+	Keys() []K
+	Values() []V
+	KeyValues() []KeyValue[K, V]
+	ForEach(f func(K, V))
+	String() string
+}
+
+// ReadOnlySet is the read-only view of an ordered set. It lets APIs accept
+// or return an ordered set without exposing the methods which modify it.
+// Set implements ReadOnlySet.
+type ReadOnlySet[T comparable] interface {
+	Contains(elem T) bool
+	Len() int
+	IsEmpty() bool
+	Elements() []T
+	ForEach(f func(T))
+	String() string
+}
+
+var (
+	_ ReadOnlyMap[int, int]    = (*Map[int, int])(nil)
+	_ ReadOnlyMap[int, int]    = (*ComparableMap[int, int])(nil)
+	_ ReadOnlyMap[int, int]    = (*VersionedMap[int, int])(nil)
+	_ ReadOnlyMap[string, int] = (*TrieMap[int])(nil)
+	_ ReadOnlySet[int]         = (*Set[int])(nil)
+)
@@ -0,0 +1,43 @@
+package ordered_test
+
+import (
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func sumValues(om ordered.ReadOnlyMap[string, int]) int {
+	sum := 0
+	om.ForEach(func(_ string, v int) {
+		sum += v
+	})
+	return sum
+}
+
+func TestReadOnlyMap(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs[string, int](kv{"a", 1}, kv{"b", 2})
+
+	var ro ordered.ReadOnlyMap[string, int] = om
+	assert.Equal(t, 2, ro.Len())
+	assert.Equal(t, []string{"a", "b"}, ro.Keys())
+	assert.Equal(t, "map{a:1 b:2}", ro.String())
+	assert.Equal(t, 3, sumValues(ro))
+
+	om.Put("c", 3)
+	assert.True(t, ro.ContainsKey("c"))
+	assert.Equal(t, 6, sumValues(ro))
+
+	assert.Equal(t, 6, sumValues(ordered.ComparableMap[string, int]{Map: om}))
+}
+
+func TestReadOnlySet(t *testing.T) {
+	s := ordered.NewSetWithElems[int](3, 1, 2)
+
+	var ro ordered.ReadOnlySet[int] = s
+	assert.True(t, ro.Contains(1))
+	assert.False(t, ro.IsEmpty())
+	assert.Equal(t, []int{3, 1, 2}, ro.Elements())
+	assert.Equal(t, "set{3 1 2}", ro.String())
+}
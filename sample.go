@@ -0,0 +1,74 @@
+package ordered
+
+import (
+	"container/list"
+	"math/rand"
+)
+
+// Sample returns n entries chosen uniformly at random from the map according
+// to their insertion order. The map is walked once by selection sampling
+// without copying its entries, and the result only depends on the state of
+// r, so a seeded r gives a reproducible sample. All the entries are returned
+// if n is at least the length of the map.
+func (o *Map[K, V]) Sample(n int, r *rand.Rand) []KeyValue[K, V] {
+	remaining := o.items.Len()
+	if n > remaining {
+		n = remaining
+	}
+	if n <= 0 {
+		return []KeyValue[K, V]{}
+	}
+	kvs := make([]KeyValue[K, V], 0, n)
+	for e := o.items.Front(); len(kvs) < n; e = e.Next() {
+		// select the entry with the probability of needed / remaining
+		if r.Intn(remaining) < n-len(kvs) {
+			key, vp := o.entry(e)
+			kvs = append(kvs, KeyValue[K, V]{Key: key, Value: vp.value})
+		}
+		remaining--
+	}
+	return kvs
+}
+
+// RandomKey returns a key chosen uniformly at random from the map and a bool
+// indicating whether the map is not empty. It takes O(n) time.
+func (o *Map[K, V]) RandomKey(r *rand.Rand) (K, bool) {
+	e := o.randomElem(r)
+	if e == nil {
+		var dummy K
+		return dummy, false
+	}
+	return o.keyOf(e), true
+}
+
+// RandomEntry returns an entry chosen uniformly at random from the map and
+// a bool indicating whether the map is not empty. It takes O(n) time.
+func (o *Map[K, V]) RandomEntry(r *rand.Rand) (KeyValue[K, V], bool) {
+	e := o.randomElem(r)
+	if e == nil {
+		return KeyValue[K, V]{}, false
+	}
+	key, vp := o.entry(e)
+	return KeyValue[K, V]{Key: key, Value: vp.value}, true
+}
+
+// randomElem returns a random element of the list walking from the nearer end.
+func (o *Map[K, V]) randomElem(r *rand.Rand) *list.Element {
+	size := o.items.Len()
+	if size == 0 {
+		return nil
+	}
+	idx := r.Intn(size)
+	if idx < size/2 {
+		e := o.items.Front()
+		for ; idx > 0; idx-- {
+			e = e.Next()
+		}
+		return e
+	}
+	e := o.items.Back()
+	for idx = size - 1 - idx; idx > 0; idx-- {
+		e = e.Prev()
+	}
+	return e
+}
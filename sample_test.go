@@ -0,0 +1,83 @@
+package ordered_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSample(t *testing.T) {
+	om := ordered.NewMap[int, int]()
+	for i := 0; i < 100; i++ {
+		om.Put(i, i*i)
+	}
+
+	t.Run("ordered and distinct", func(t *testing.T) {
+		kvs := om.Sample(10, rand.New(rand.NewSource(1)))
+		assert.Len(t, kvs, 10)
+		for i, kv := range kvs {
+			assert.Equal(t, kv.Key*kv.Key, kv.Value)
+			if i > 0 {
+				assert.Less(t, kvs[i-1].Key, kv.Key)
+			}
+		}
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		a := om.Sample(5, rand.New(rand.NewSource(42)))
+		b := om.Sample(5, rand.New(rand.NewSource(42)))
+		assert.Equal(t, a, b)
+	})
+
+	t.Run("bounds", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		assert.Equal(t, om.KeyValues(), om.Sample(200, r))
+		assert.Empty(t, om.Sample(0, r))
+		assert.Empty(t, om.Sample(-1, r))
+		assert.Empty(t, ordered.NewMap[int, int]().Sample(3, r))
+	})
+
+	t.Run("uniform", func(t *testing.T) {
+		small := ordered.NewMap[int, int]()
+		for i := 0; i < 4; i++ {
+			small.Put(i, i)
+		}
+		r := rand.New(rand.NewSource(7))
+		counts := make([]int, 4)
+		for i := 0; i < 4000; i++ {
+			for _, kv := range small.Sample(2, r) {
+				counts[kv.Key]++
+			}
+		}
+		for _, c := range counts {
+			assert.InDelta(t, 2000, c, 200)
+		}
+	})
+}
+
+func TestRandomKey(t *testing.T) {
+	om := ordered.NewMap[string, int]()
+	r := rand.New(rand.NewSource(1))
+
+	_, ok := om.RandomKey(r)
+	assert.False(t, ok)
+	_, ok = om.RandomEntry(r)
+	assert.False(t, ok)
+
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		om.Put(key, i)
+	}
+	seen := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		key, ok := om.RandomKey(r)
+		assert.True(t, ok)
+		seen[key]++
+
+		kv, ok := om.RandomEntry(r)
+		assert.True(t, ok)
+		assert.Equal(t, om.GetOrDefault(kv.Key, -1), kv.Value)
+	}
+	assert.Len(t, seen, 5)
+}
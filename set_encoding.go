@@ -0,0 +1,304 @@
+package ordered
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ElementEncoding selects how the elements of a set are represented in JSON.
+type ElementEncoding int
+
+const (
+	// AsDefault encodes the elements like json.Marshal does i.e. through
+	// json.Marshaler or encoding.TextMarshaler whichever is implemented.
+	AsDefault ElementEncoding = iota
+	// AsText encodes every element as a JSON string through its
+	// encoding.TextMarshaler implementation. String elements are encoded as
+	// they are and the elements of other types fail to encode.
+	AsText
+	// AsJSON encodes every element structurally and ignores its
+	// encoding.TextMarshaler implementation. A json.Marshaler
+	// implementation is still used.
+	AsJSON
+)
+
+// SetElementEncoding sets the representation of the elements used by
+// MarshalJSON and UnmarshalJSON of the set.
+func (s *Set[T]) SetElementEncoding(enc ElementEncoding) {
+	s.enc = enc
+}
+
+// MarshalJSONAs marshals the set to JSON like MarshalJSON but represents the
+// elements according to the given encoding.
+func (s Set[T]) MarshalJSONAs(enc ElementEncoding) ([]byte, error) {
+	s.enc = enc
+	return s.MarshalJSON()
+}
+
+// marshalElem marshals a set element according to the encoding.
+func marshalElem[T any](elem T, enc ElementEncoding) ([]byte, error) {
+	switch enc {
+	case AsText:
+		if tm, ok := any(&elem).(encoding.TextMarshaler); ok {
+			text, err := tm.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(string(text))
+		}
+		if str, ok := any(elem).(string); ok {
+			return json.Marshal(str)
+		}
+		return nil, errors.New("element does not implement encoding.TextMarshaler")
+	case AsJSON:
+		if isJSONMarshaler(elem) {
+			return json.Marshal(elem)
+		}
+		rv := reflect.ValueOf(&elem).Elem()
+		return json.Marshal(toPlain(rv).Interface())
+	default:
+		return json.Marshal(elem)
+	}
+}
+
+// unmarshalElem unmarshals a set element according to the encoding.
+func unmarshalElem[T any](b []byte, elem *T, enc ElementEncoding) error {
+	switch enc {
+	case AsText:
+		if tu, ok := any(elem).(encoding.TextUnmarshaler); ok {
+			var text string
+			if err := json.Unmarshal(b, &text); err != nil {
+				return err
+			}
+			return tu.UnmarshalText([]byte(text))
+		}
+		if _, ok := any(*elem).(string); ok {
+			return json.Unmarshal(b, elem)
+		}
+		return errors.New("element does not implement encoding.TextUnmarshaler")
+	case AsJSON:
+		if isJSONUnmarshaler(elem) {
+			return json.Unmarshal(b, elem)
+		}
+		rv := reflect.ValueOf(elem).Elem()
+		pv := reflect.New(plainType(rv.Type()))
+		if err := json.Unmarshal(b, pv.Interface()); err != nil {
+			return err
+		}
+		return fromPlain(rv, pv.Elem())
+	default:
+		return json.Unmarshal(b, elem)
+	}
+}
+
+func isJSONMarshaler[T any](elem T) bool {
+	_, ok := any(elem).(json.Marshaler)
+	_, pok := any(&elem).(json.Marshaler)
+	return ok || pok
+}
+
+func isJSONUnmarshaler[T any](elem *T) bool {
+	_, ok := any(elem).(json.Unmarshaler)
+	_, vok := any(*elem).(json.Unmarshaler)
+	return ok || vok
+}
+
+// plainInfo is the plain type of a type and the indices of its fields
+// returned by plainFields if it is a struct type.
+type plainInfo struct {
+	typ    reflect.Type
+	fields []int
+}
+
+// plainInfos caches the plainInfo of the element types, so that the plain
+// types are built once per type instead of once per element.
+var plainInfos sync.Map
+
+// plainInfoOf returns the cached plainInfo of t building it if needed.
+func plainInfoOf(t reflect.Type) *plainInfo {
+	if info, ok := plainInfos.Load(t); ok {
+		return info.(*plainInfo)
+	}
+	info := &plainInfo{typ: buildPlainType(t)}
+	if t.Kind() == reflect.Struct {
+		info.fields = plainFields(t)
+	}
+	actual, _ := plainInfos.LoadOrStore(t, info)
+	return actual.(*plainInfo)
+}
+
+// plainType returns a type without methods which has the same JSON
+// structure as t.
+func plainType(t reflect.Type) reflect.Type {
+	return plainInfoOf(t).typ
+}
+
+func buildPlainType(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return reflect.PointerTo(plainType(t.Elem()))
+	case reflect.Struct:
+		var fields []reflect.StructField
+		for _, i := range plainFields(t) {
+			f := t.Field(i)
+			if embedsStruct(f) {
+				// the plain type has no methods, so it can stay embedded and
+				// encoding/json promotes its fields like the original ones
+				f.Type = plainType(f.Type)
+				if !f.IsExported() {
+					f.Name, f.PkgPath = exportedName(t, f.Name), ""
+				}
+			} else {
+				// reflect.StructOf does not support embedded types with methods
+				f.Anonymous = f.Anonymous && f.Type.NumMethod() == 0 && reflect.PointerTo(f.Type).NumMethod() == 0
+			}
+			fields = append(fields, f)
+		}
+		return reflect.StructOf(fields)
+	case reflect.Slice:
+		return reflect.SliceOf(t.Elem())
+	case reflect.Array:
+		return reflect.ArrayOf(t.Len(), t.Elem())
+	case reflect.Map:
+		return reflect.MapOf(t.Key(), t.Elem())
+	case reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return t
+	default:
+		return basicType(t.Kind())
+	}
+}
+
+// plainFields returns the indices of the fields of the struct type t which
+// are seen by encoding/json i.e. the exported fields and the embedded
+// structs, whose exported fields are promoted even if they are unexported.
+func plainFields(t reflect.Type) []int {
+	var fields []int
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() || embedsStruct(f) {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}
+
+// embedsStruct checks if f is an embedded struct or pointer to struct.
+func embedsStruct(f reflect.StructField) bool {
+	t := f.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return f.Anonymous && t.Kind() == reflect.Struct
+}
+
+// exportedName returns an exported field name for the unexported embedded
+// field name which does not collide with the other fields of t.
+func exportedName(t reflect.Type, name string) string {
+	name = "X" + name
+	for _, ok := t.FieldByName(name); ok; _, ok = t.FieldByName(name) {
+		name = "X" + name
+	}
+	return name
+}
+
+// toPlain converts v to the value of its plain type.
+func toPlain(v reflect.Value) reflect.Value {
+	info := plainInfoOf(v.Type())
+	pt := info.typ
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(pt)
+		}
+		pv := reflect.New(pt.Elem())
+		pv.Elem().Set(toPlain(v.Elem()))
+		return pv
+	case reflect.Struct:
+		pv := reflect.New(pt).Elem()
+		for j, i := range info.fields {
+			if fv := v.Field(i); fv.Type() == pt.Field(j).Type {
+				pv.Field(j).Set(fv)
+			} else {
+				pv.Field(j).Set(toPlain(fv))
+			}
+		}
+		return pv
+	default:
+		return v.Convert(pt)
+	}
+}
+
+// fromPlain sets v from pv which is a value of the plain type of v.
+func fromPlain(v, pv reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if pv.IsNil() {
+			if v.CanSet() {
+				v.Set(reflect.Zero(v.Type()))
+			}
+			return nil
+		}
+		if v.IsNil() {
+			if !v.CanSet() {
+				return fmt.Errorf("cannot set embedded pointer to unexported struct %v", v.Type().Elem())
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return fromPlain(v.Elem(), pv.Elem())
+	case reflect.Struct:
+		for j, i := range plainInfoOf(v.Type()).fields {
+			fv := v.Field(i)
+			if fv.Type() == pv.Field(j).Type() {
+				fv.Set(pv.Field(j))
+			} else if err := fromPlain(fv, pv.Field(j)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		v.Set(pv.Convert(v.Type()))
+		return nil
+	}
+}
+
+func basicType(k reflect.Kind) reflect.Type {
+	switch k {
+	case reflect.Bool:
+		return reflect.TypeOf(false)
+	case reflect.Int:
+		return reflect.TypeOf(int(0))
+	case reflect.Int8:
+		return reflect.TypeOf(int8(0))
+	case reflect.Int16:
+		return reflect.TypeOf(int16(0))
+	case reflect.Int32:
+		return reflect.TypeOf(int32(0))
+	case reflect.Int64:
+		return reflect.TypeOf(int64(0))
+	case reflect.Uint:
+		return reflect.TypeOf(uint(0))
+	case reflect.Uint8:
+		return reflect.TypeOf(uint8(0))
+	case reflect.Uint16:
+		return reflect.TypeOf(uint16(0))
+	case reflect.Uint32:
+		return reflect.TypeOf(uint32(0))
+	case reflect.Uint64:
+		return reflect.TypeOf(uint64(0))
+	case reflect.Uintptr:
+		return reflect.TypeOf(uintptr(0))
+	case reflect.Float32:
+		return reflect.TypeOf(float32(0))
+	case reflect.Float64:
+		return reflect.TypeOf(float64(0))
+	case reflect.Complex64:
+		return reflect.TypeOf(complex64(0))
+	case reflect.Complex128:
+		return reflect.TypeOf(complex128(0))
+	default:
+		return reflect.TypeOf("")
+	}
+}
@@ -0,0 +1,204 @@
+package ordered_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+type shout string
+
+func (s shout) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(s))), nil
+}
+
+func (s *shout) UnmarshalText(text []byte) error {
+	*s = shout(strings.ToLower(string(text)))
+	return nil
+}
+
+type inner struct{ A int }
+
+func (inner) String() string { return "inner" }
+
+type Inner struct{ A int }
+
+func (Inner) String() string { return "Inner" }
+
+type outer struct {
+	Inner
+	B int
+}
+
+type hidden struct {
+	inner
+	B int
+}
+
+type hiddenPtr struct {
+	*inner
+	B int
+}
+
+func TestSetElementEncodingAsText(t *testing.T) {
+	t.Run("text marshaler", func(t *testing.T) {
+		s := ordered.NewSetWithElems[point3d](point3d{1, 2, 3}, point3d{4, 5, 6})
+		s.SetElementEncoding(ordered.AsText)
+
+		b, err := json.Marshal(s)
+		assert.NoError(t, err)
+		assert.Equal(t, `["1-2-3","4-5-6"]`, string(b))
+
+		ds := ordered.NewSet[point3d]()
+		ds.SetElementEncoding(ordered.AsText)
+		assert.NoError(t, json.Unmarshal(b, ds))
+		assert.Equal(t, []point3d{{1, 2, 3}, {4, 5, 6}}, ds.Elements())
+	})
+
+	t.Run("string", func(t *testing.T) {
+		s := ordered.NewSetWithElems[string]("foo", "bar")
+
+		b, err := s.MarshalJSONAs(ordered.AsText)
+		assert.NoError(t, err)
+		assert.Equal(t, `["foo","bar"]`, string(b))
+
+		ds := ordered.NewSet[string]()
+		ds.SetElementEncoding(ordered.AsText)
+		assert.NoError(t, ds.UnmarshalJSON(b))
+		assert.Equal(t, []string{"foo", "bar"}, ds.Elements())
+	})
+
+	t.Run("no text marshaler", func(t *testing.T) {
+		type st struct{ Val int }
+		s := ordered.NewSetWithElems[st](st{1})
+
+		_, err := s.MarshalJSONAs(ordered.AsText)
+		assert.Error(t, err)
+
+		ds := ordered.NewSet[st]()
+		ds.SetElementEncoding(ordered.AsText)
+		assert.Error(t, ds.UnmarshalJSON([]byte(`["1"]`)))
+	})
+
+	t.Run("text marshalling error", func(t *testing.T) {
+		s := ordered.NewSetWithElems[errKey](errKey{})
+
+		_, err := s.MarshalJSONAs(ordered.AsText)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetElementEncodingAsJSON(t *testing.T) {
+	t.Run("struct with text marshaler", func(t *testing.T) {
+		s := ordered.NewSetWithElems[point3d](point3d{1, 2, 3}, point3d{4, 5, 6})
+		s.SetElementEncoding(ordered.AsJSON)
+
+		b, err := json.Marshal(s)
+		assert.NoError(t, err)
+		assert.Equal(t, `[{"X":1,"Y":2,"Z":3},{"X":4,"Y":5,"Z":6}]`, string(b))
+
+		ds := ordered.NewSet[point3d]()
+		ds.SetElementEncoding(ordered.AsJSON)
+		assert.NoError(t, json.Unmarshal(b, ds))
+		assert.Equal(t, []point3d{{1, 2, 3}, {4, 5, 6}}, ds.Elements())
+	})
+
+	t.Run("named string with text marshaler", func(t *testing.T) {
+		s := ordered.NewSetWithElems[shout]("foo", "bar")
+
+		b, err := s.MarshalJSONAs(ordered.AsDefault)
+		assert.NoError(t, err)
+		assert.Equal(t, `["FOO","BAR"]`, string(b))
+
+		b, err = s.MarshalJSONAs(ordered.AsJSON)
+		assert.NoError(t, err)
+		assert.Equal(t, `["foo","bar"]`, string(b))
+
+		ds := ordered.NewSet[shout]()
+		ds.SetElementEncoding(ordered.AsJSON)
+		assert.NoError(t, ds.UnmarshalJSON([]byte(`["Foo"]`)))
+		assert.Equal(t, []shout{"Foo"}, ds.Elements())
+	})
+
+	t.Run("pointer elements", func(t *testing.T) {
+		p := &point3d{7, 8, 9}
+		s := ordered.NewSetWithElems[*point3d](p, nil)
+
+		b, err := s.MarshalJSONAs(ordered.AsJSON)
+		assert.NoError(t, err)
+		assert.Equal(t, `[{"X":7,"Y":8,"Z":9},null]`, string(b))
+
+		ds := ordered.NewSet[*point3d]()
+		ds.SetElementEncoding(ordered.AsJSON)
+		assert.NoError(t, ds.UnmarshalJSON([]byte(`[{"X":7,"Y":8,"Z":9}]`)))
+		assert.Equal(t, point3d{7, 8, 9}, *ds.Elements()[0])
+	})
+
+	t.Run("json marshaler is used", func(t *testing.T) {
+		s := ordered.NewSetWithElems[*ordered.Set[int]](ordered.NewSetWithElems[int](1, 2))
+
+		b, err := s.MarshalJSONAs(ordered.AsJSON)
+		assert.NoError(t, err)
+		assert.Equal(t, `[[1,2]]`, string(b))
+
+		ds := ordered.NewSet[*ordered.Set[int]]()
+		ds.SetElementEncoding(ordered.AsJSON)
+		assert.NoError(t, ds.UnmarshalJSON(b))
+		assert.Equal(t, []int{1, 2}, ds.Elements()[0].Elements())
+	})
+
+	t.Run("set inside struct", func(t *testing.T) {
+		type st struct {
+			Points *ordered.Set[point3d]
+		}
+		data := st{Points: ordered.NewSetWithElems[point3d](point3d{1, 2, 3})}
+		data.Points.SetElementEncoding(ordered.AsJSON)
+
+		b, err := json.Marshal(data)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"Points":[{"X":1,"Y":2,"Z":3}]}`, string(b))
+	})
+
+	t.Run("embedded struct with methods", func(t *testing.T) {
+		s := ordered.NewSetWithElems[outer](outer{Inner{1}, 2})
+
+		b, err := s.MarshalJSONAs(ordered.AsJSON)
+		assert.NoError(t, err)
+		assert.Equal(t, `[{"A":1,"B":2}]`, string(b))
+
+		ds := ordered.NewSet[outer]()
+		ds.SetElementEncoding(ordered.AsJSON)
+		assert.NoError(t, ds.UnmarshalJSON(b))
+		assert.Equal(t, []outer{{Inner{1}, 2}}, ds.Elements())
+	})
+
+	t.Run("unexported embedded struct", func(t *testing.T) {
+		s := ordered.NewSetWithElems[hidden](hidden{inner{1}, 2})
+
+		b, err := s.MarshalJSONAs(ordered.AsJSON)
+		assert.NoError(t, err)
+		assert.Equal(t, `[{"A":1,"B":2}]`, string(b))
+
+		ds := ordered.NewSet[hidden]()
+		ds.SetElementEncoding(ordered.AsJSON)
+		assert.NoError(t, ds.UnmarshalJSON(b))
+		assert.Equal(t, []hidden{{inner{1}, 2}}, ds.Elements())
+	})
+
+	t.Run("unexported embedded pointer", func(t *testing.T) {
+		s := ordered.NewSetWithElems[hiddenPtr](hiddenPtr{&inner{1}, 2}, hiddenPtr{nil, 3})
+
+		b, err := s.MarshalJSONAs(ordered.AsJSON)
+		assert.NoError(t, err)
+		assert.Equal(t, `[{"A":1,"B":2},{"B":3}]`, string(b))
+
+		ds := ordered.NewSet[hiddenPtr]()
+		ds.SetElementEncoding(ordered.AsJSON)
+		assert.NoError(t, ds.UnmarshalJSON([]byte(`[{"B":3}]`)))
+		assert.Equal(t, []hiddenPtr{{nil, 3}}, ds.Elements())
+		assert.Error(t, ds.UnmarshalJSON([]byte(`[{"A":1,"B":2}]`)))
+	})
+}
@@ -0,0 +1,265 @@
+package ordered
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type shardEntry[V any] struct {
+	value V
+	seq   uint64
+}
+
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	mp *Map[K, shardEntry[V]]
+}
+
+// ShardedMap is an ordered map which is safe for concurrent use. The keys
+// are spread over a number of shards by their hash and every shard has its
+// own lock, so that the writers of different shards do not contend. Every
+// inserted key gets a sequence number which is used to merge the shards
+// back into the global insertion order on iteration.
+type ShardedMap[K comparable, V any] struct {
+	shards []*shard[K, V]
+	hash   func(K) uint64
+	seq    uint64
+}
+
+// NewShardedMap initializes a concurrent ordered map with the given number
+// of shards. The shard of a key is selected by the given hash function e.g.
+// one built on hash/maphash. At least one shard is created.
+func NewShardedMap[K comparable, V any](shards int, hash func(K) uint64) *ShardedMap[K, V] {
+	if shards < 1 {
+		shards = 1
+	}
+	o := &ShardedMap[K, V]{
+		shards: make([]*shard[K, V], shards),
+		hash:   hash,
+	}
+	for i := range o.shards {
+		o.shards[i] = &shard[K, V]{mp: NewMap[K, shardEntry[V]]()}
+	}
+	return o
+}
+
+func (o *ShardedMap[K, V]) shard(key K) *shard[K, V] {
+	return o.shards[o.hash(key)%uint64(len(o.shards))]
+}
+
+// Put inserts a key and its mapped value in the map. If the key already exists, the
+// mapped value is replaced by the new value.
+func (o *ShardedMap[K, V]) Put(key K, value V) {
+	s := o.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.mp.Get(key)
+	if !ok {
+		// the sequence number is taken under the shard lock, so that the
+		// entries of a shard are always sorted by their sequence numbers
+		entry.seq = atomic.AddUint64(&o.seq, 1)
+	}
+	entry.value = value
+	s.mp.Put(key, entry)
+}
+
+// Get returns the mapped value for the given key and a bool indicating
+// whether the key exists or not.
+func (o *ShardedMap[K, V]) Get(key K) (V, bool) {
+	s := o.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.mp.Get(key)
+	return entry.value, ok
+}
+
+// GetOrDefault returns the mapped value for the given key if it exists.
+// Otherwise, it returns the default value.
+func (o *ShardedMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if val, ok := o.Get(key); ok {
+		return val
+	}
+	return defaultValue
+}
+
+// ContainsKey checks if the map contains a mapping for the given key.
+func (o *ShardedMap[K, V]) ContainsKey(key K) bool {
+	_, ok := o.Get(key)
+	return ok
+}
+
+// Remove removes the key with its mapped value from the map and returns
+// the value if the key exists.
+func (o *ShardedMap[K, V]) Remove(key K) V {
+	s := o.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mp.Remove(key).value
+}
+
+// Len returns the number of elements in the map.
+func (o *ShardedMap[K, V]) Len() int {
+	o.rlockAll()
+	defer o.runlockAll()
+	n := 0
+	for _, s := range o.shards {
+		n += s.mp.Len()
+	}
+	return n
+}
+
+// Keys returns all the keys from the map according to their insertion order.
+// The first element of the slice is the oldest key in the map.
+func (o *ShardedMap[K, V]) Keys() []K {
+	kvs := o.KeyValues()
+	keys := make([]K, len(kvs))
+	for i, kv := range kvs {
+		keys[i] = kv.Key
+	}
+	return keys
+}
+
+// Values returns all the values from the map according to their insertion order.
+// The first element of the slice is the oldest value in the map.
+func (o *ShardedMap[K, V]) Values() []V {
+	kvs := o.KeyValues()
+	values := make([]V, len(kvs))
+	for i, kv := range kvs {
+		values[i] = kv.Value
+	}
+	return values
+}
+
+// KeyValues returns all the keys and values from the map according to their
+// insertion order. The first element of the slice is the oldest key and value
+// in the map. All the shards are locked while the entries are collected, so
+// the result is a consistent snapshot of the map.
+func (o *ShardedMap[K, V]) KeyValues() []KeyValue[K, V] {
+	o.rlockAll()
+	runs := make(mergeHeap[K, V], 0, len(o.shards))
+	total := 0
+	for _, s := range o.shards {
+		if kvs := s.mp.KeyValues(); len(kvs) > 0 {
+			runs = append(runs, kvs)
+			total += len(kvs)
+		}
+	}
+	o.runlockAll()
+
+	kvs := make([]KeyValue[K, V], 0, total)
+	heap.Init(&runs)
+	for len(runs) > 0 {
+		kv := runs[0][0]
+		kvs = append(kvs, KeyValue[K, V]{Key: kv.Key, Value: kv.Value.value})
+		if runs[0] = runs[0][1:]; len(runs[0]) == 0 {
+			heap.Pop(&runs)
+		} else {
+			heap.Fix(&runs, 0)
+		}
+	}
+	return kvs
+}
+
+// ForEach invokes the given function f for each element of a snapshot of the
+// map. The map can be modified by f.
+func (o *ShardedMap[K, V]) ForEach(f func(K, V)) {
+	for _, kv := range o.KeyValues() {
+		f(kv.Key, kv.Value)
+	}
+}
+
+// IsEmpty checks whether the map is empty or not.
+func (o *ShardedMap[K, V]) IsEmpty() bool {
+	return o.Len() == 0
+}
+
+// Clear removes all the keys and their mapped values from the map.
+func (o *ShardedMap[K, V]) Clear() {
+	for _, s := range o.shards {
+		s.mu.Lock()
+	}
+	for _, s := range o.shards {
+		s.mp.Clear()
+		s.mu.Unlock()
+	}
+}
+
+// String returns the string representation of the map.
+func (o *ShardedMap[K, V]) String() string {
+	var sb strings.Builder
+	sb.WriteString("map{")
+	for idx, kv := range o.KeyValues() {
+		if idx > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(fmt.Sprint(kv.Key))
+		sb.WriteByte(':')
+		sb.WriteString(fmt.Sprint(kv.Value))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// Snapshot returns a copy of the map as a Map. All the shards are locked
+// while the copy is taken, so the copy is never torn by concurrent writers.
+func (o *ShardedMap[K, V]) Snapshot() *Map[K, V] {
+	return NewMapFromKVs(o.KeyValues())
+}
+
+// MarshalJSON implements json.Marshaler interface. It marshals a snapshot of
+// the map, so it is safe to call while other goroutines modify the map.
+func (o *ShardedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return o.Snapshot().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. The map must be
+// initialized by NewShardedMap since the hash function is required.
+func (o *ShardedMap[K, V]) UnmarshalJSON(b []byte) error {
+	if o.shards == nil {
+		return errors.New("uninitialized map")
+	}
+	om := NewMap[K, V]()
+	if err := om.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	for _, kv := range om.KeyValues() {
+		o.Put(kv.Key, kv.Value)
+	}
+	return nil
+}
+
+// rlockAll read locks all the shards in a fixed order.
+func (o *ShardedMap[K, V]) rlockAll() {
+	for _, s := range o.shards {
+		s.mu.RLock()
+	}
+}
+
+func (o *ShardedMap[K, V]) runlockAll() {
+	for _, s := range o.shards {
+		s.mu.RUnlock()
+	}
+}
+
+// mergeHeap is a min-heap of the entry runs of the shards ordered by the
+// sequence number of their first entries.
+type mergeHeap[K comparable, V any] [][]KeyValue[K, shardEntry[V]]
+
+func (h mergeHeap[K, V]) Len() int { return len(h) }
+
+func (h mergeHeap[K, V]) Less(i, j int) bool { return h[i][0].Value.seq < h[j][0].Value.seq }
+
+func (h mergeHeap[K, V]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *mergeHeap[K, V]) Push(x any) { *h = append(*h, x.([]KeyValue[K, shardEntry[V]])) }
+
+func (h *mergeHeap[K, V]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
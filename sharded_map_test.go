@@ -0,0 +1,164 @@
+package ordered_test
+
+import (
+	"encoding/json"
+	"hash/maphash"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+var seed = maphash.MakeSeed()
+
+func hashString(s string) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	h.WriteString(s)
+	return h.Sum64()
+}
+
+func hashInt(i int) uint64 {
+	return uint64(i)
+}
+
+func TestShardedMap(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewShardedMap[string, int](4, hashString)
+	assert.True(t, om.IsEmpty())
+
+	for i, key := range []string{"e", "d", "c", "b", "a", "f", "g"} {
+		om.Put(key, i)
+	}
+	om.Put("c", 20)
+	om.Remove("d")
+	om.Put("d", 30)
+
+	assert.Equal(t, 7, om.Len())
+	assert.Equal(t, []string{"e", "c", "b", "a", "f", "g", "d"}, om.Keys())
+	assert.Equal(t, []int{0, 20, 3, 4, 5, 6, 30}, om.Values())
+
+	val, ok := om.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 20, val)
+	assert.True(t, om.ContainsKey("a"))
+	assert.False(t, om.ContainsKey("z"))
+	assert.Equal(t, -1, om.GetOrDefault("z", -1))
+	assert.Equal(t, 0, om.Remove("z"))
+
+	var kvs []kv
+	om.ForEach(func(k string, v int) {
+		kvs = append(kvs, kv{k, v})
+	})
+	assert.Equal(t, om.KeyValues(), kvs)
+
+	om.Clear()
+	assert.True(t, om.IsEmpty())
+	assert.Equal(t, "map{}", om.String())
+}
+
+func TestShardedMapSingleShard(t *testing.T) {
+	om := ordered.NewShardedMap[int, string](0, hashInt)
+	om.Put(3, "c")
+	om.Put(1, "a")
+
+	assert.Equal(t, "map{3:c 1:a}", om.String())
+}
+
+func TestShardedMapConcurrent(t *testing.T) {
+	om := ordered.NewShardedMap[int, int](8, hashInt)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				om.Put(w*1000+i, i)
+				if i%10 == 0 {
+					om.Keys()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert.Equal(t, 8000, om.Len())
+
+	// the keys of every writer keep their relative insertion order
+	keys := om.Keys()
+	last := make(map[int]int)
+	for _, key := range keys {
+		w := key / 1000
+		if prev, ok := last[w]; ok {
+			assert.Less(t, prev, key)
+		}
+		last[w] = key
+	}
+	sort.Ints(keys)
+	assert.Equal(t, 0, keys[0])
+	assert.Equal(t, 7999, keys[len(keys)-1])
+}
+
+func TestShardedMapJSON(t *testing.T) {
+	om := ordered.NewShardedMap[string, int](4, hashString)
+	om.Put("b", 2)
+	om.Put("a", 1)
+	om.Put("c", 3)
+
+	b, err := json.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b":2,"a":1,"c":3}`, string(b))
+
+	snapshot := om.Snapshot()
+	om.Remove("a")
+	assert.Equal(t, []string{"b", "a", "c"}, snapshot.Keys())
+
+	got := ordered.NewShardedMap[string, int](2, hashString)
+	assert.NoError(t, json.Unmarshal(b, got))
+	assert.Equal(t, []string{"b", "a", "c"}, got.Keys())
+
+	var uninit ordered.ShardedMap[string, int]
+	assert.Error(t, json.Unmarshal(b, &uninit))
+}
+
+func TestShardedMapJSONConcurrent(t *testing.T) {
+	om := ordered.NewShardedMap[int, int](8, hashInt)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			// an odd key is inserted after and removed before its even pair
+			om.Put(2*i, i)
+			om.Put(2*i+1, i)
+			if i%3 == 0 {
+				om.Remove(2*i + 1)
+				om.Remove(2 * i)
+			}
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		b, err := json.Marshal(om)
+		assert.NoError(t, err)
+		got := ordered.NewMap[int, int]()
+		assert.NoError(t, json.Unmarshal(b, got))
+		keys := got.Keys()
+		assert.True(t, sort.IntsAreSorted(keys))
+		for _, key := range keys {
+			if key%2 == 1 {
+				assert.True(t, got.ContainsKey(key-1))
+			}
+		}
+	}
+	close(done)
+	wg.Wait()
+}
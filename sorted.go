@@ -0,0 +1,38 @@
+//go:build go1.21
+
+package ordered
+
+import (
+	"cmp"
+	"slices"
+)
+
+// KeysSorted returns all the keys from the map in ascending order. The
+// insertion order of the map is not changed.
+func KeysSorted[K cmp.Ordered, V any](om *Map[K, V]) []K {
+	keys := om.Keys()
+	slices.Sort(keys)
+	return keys
+}
+
+// ValuesSortedByKey returns all the values from the map in ascending order
+// of their keys. The insertion order of the map is not changed.
+func ValuesSortedByKey[K cmp.Ordered, V any](om *Map[K, V]) []V {
+	kvs := KeyValuesSortedByKey(om)
+	values := make([]V, len(kvs))
+	for i, kv := range kvs {
+		values[i] = kv.Value
+	}
+	return values
+}
+
+// KeyValuesSortedByKey returns all the keys and values from the map in
+// ascending order of the keys. The insertion order of the map is not
+// changed.
+func KeyValuesSortedByKey[K cmp.Ordered, V any](om *Map[K, V]) []KeyValue[K, V] {
+	kvs := om.KeyValues()
+	slices.SortFunc(kvs, func(a, b KeyValue[K, V]) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return kvs
+}
@@ -0,0 +1,37 @@
+//go:build go1.21
+
+package ordered_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysSorted(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs[string, int](kv{"pear", 3}, kv{"apple", 1}, kv{"fig", 2})
+
+	assert.Equal(t, []string{"apple", "fig", "pear"}, ordered.KeysSorted(om))
+	assert.Equal(t, []string{"pear", "apple", "fig"}, om.Keys())
+}
+
+func TestValuesSortedByKey(t *testing.T) {
+	type kv = ordered.KeyValue[int, string]
+	om := ordered.NewMapWithKVs[int, string](kv{3, "c"}, kv{-1, "a"}, kv{2, "b"})
+
+	assert.Equal(t, []string{"a", "b", "c"}, ordered.ValuesSortedByKey(om))
+	assert.Equal(t, []string{"c", "a", "b"}, om.Values())
+}
+
+func TestKeyValuesSortedByKey(t *testing.T) {
+	type kv = ordered.KeyValue[float64, int]
+	om := ordered.NewMapWithKVs[float64, int](kv{2.5, 1}, kv{math.Inf(-1), 2}, kv{0, 3})
+
+	assert.Equal(t, []kv{{math.Inf(-1), 2}, {0, 3}, {2.5, 1}}, ordered.KeyValuesSortedByKey(om))
+	assert.Equal(t, []kv{{2.5, 1}, {math.Inf(-1), 2}, {0, 3}}, om.KeyValues())
+
+	assert.Equal(t, []kv{}, ordered.KeyValuesSortedByKey(ordered.NewMap[float64, int]()))
+}
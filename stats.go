@@ -0,0 +1,99 @@
+package ordered
+
+import "sync/atomic"
+
+// Stats is a snapshot of the operation counters of a map or set created
+// with the WithStats option.
+type Stats struct {
+	// Len is the number of entries.
+	Len int
+	// Puts is the number of insertions and updates.
+	Puts uint64
+	// Gets is the number of lookups by Get, GetOrDefault and ContainsKey.
+	Gets uint64
+	// Hits is the number of lookups which found the key.
+	Hits uint64
+	// Misses is the number of lookups which did not find the key.
+	Misses uint64
+	// Removes is the number of removed entries.
+	Removes uint64
+}
+
+// stats holds the operation counters. The counters are updated atomically,
+// so that a snapshot can be read while the owner is being modified. All the
+// methods are no-op on a nil receiver.
+type stats struct {
+	len     int64
+	puts    uint64
+	hits    uint64
+	misses  uint64
+	removes uint64
+}
+
+func (s *stats) put(inserted bool) {
+	if s == nil {
+		return
+	}
+	atomic.AddUint64(&s.puts, 1)
+	if inserted {
+		atomic.AddInt64(&s.len, 1)
+	}
+}
+
+func (s *stats) get(hit bool) {
+	if s == nil {
+		return
+	}
+	if hit {
+		atomic.AddUint64(&s.hits, 1)
+	} else {
+		atomic.AddUint64(&s.misses, 1)
+	}
+}
+
+func (s *stats) remove(n int) {
+	if s == nil {
+		return
+	}
+	atomic.AddUint64(&s.removes, uint64(n))
+	atomic.AddInt64(&s.len, -int64(n))
+}
+
+func (s *stats) restore() {
+	if s == nil {
+		return
+	}
+	atomic.AddInt64(&s.len, 1)
+}
+
+func (s *stats) snapshot() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	hits := atomic.LoadUint64(&s.hits)
+	misses := atomic.LoadUint64(&s.misses)
+	return Stats{
+		Len:     int(atomic.LoadInt64(&s.len)),
+		Puts:    atomic.LoadUint64(&s.puts),
+		Gets:    hits + misses,
+		Hits:    hits,
+		Misses:  misses,
+		Removes: atomic.LoadUint64(&s.removes),
+	}
+}
+
+// Stats returns a snapshot of the operation counters of the map. It is safe
+// to call Stats concurrently with the other methods, e.g. from a metrics
+// collector. It returns zero counters if the map is not created with the
+// WithStats option.
+func (o *Map[K, V]) Stats() Stats {
+	return o.stats.snapshot()
+}
+
+// Stats returns a snapshot of the operation counters of the set. Adding an
+// element counts as a put and checking an element counts as a get. It is
+// safe to call Stats concurrently with the other methods. It returns zero
+// counters if the set is not created with the WithStats option.
+func (s *Set[T]) Stats() Stats {
+	return s.mp.Stats()
+}
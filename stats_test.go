@@ -0,0 +1,86 @@
+package ordered_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapStats(t *testing.T) {
+	om := ordered.NewMap[string, int](ordered.WithStats())
+	om.Put("a", 1)
+	om.Put("b", 2)
+	om.Put("a", 3)
+	om.Get("a")
+	om.Get("z")
+	om.GetOrDefault("b", 0)
+	om.ContainsKey("y")
+	om.Remove("b")
+	om.Remove("z")
+
+	assert.Equal(t, ordered.Stats{
+		Len:     1,
+		Puts:    3,
+		Gets:    4,
+		Hits:    2,
+		Misses:  2,
+		Removes: 1,
+	}, om.Stats())
+
+	om.Put("c", 4)
+	om.Clear()
+	stats := om.Stats()
+	assert.Equal(t, 0, stats.Len)
+	assert.Equal(t, uint64(3), stats.Removes)
+}
+
+func TestMapStatsDisabled(t *testing.T) {
+	om := ordered.NewMap[string, int]()
+	om.Put("a", 1)
+	om.Get("a")
+
+	assert.Equal(t, ordered.Stats{}, om.Stats())
+}
+
+func TestMapStatsConcurrentRead(t *testing.T) {
+	om := ordered.NewMap[int, int](ordered.WithStats())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			om.Stats()
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		om.Put(i, i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1000, om.Stats().Len)
+}
+
+func TestSetStats(t *testing.T) {
+	s := ordered.NewSet[string](ordered.WithStats())
+	s.Add("a")
+	s.Add("b")
+	s.Contains("a")
+	s.Contains("c")
+
+	stats := s.Stats()
+	assert.Equal(t, 2, stats.Len)
+	assert.Equal(t, uint64(2), stats.Puts)
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+
+	assert.True(t, s.Remove("a"))
+	assert.False(t, s.Remove("c"))
+	stats = s.Stats()
+	assert.Equal(t, 1, stats.Len)
+	assert.Equal(t, uint64(2), stats.Gets)
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Removes)
+}
@@ -0,0 +1,104 @@
+package ordered
+
+import (
+	"encoding/gob"
+	"io"
+)
+
+// WriteTo implements io.WriterTo interface. It writes the map to w in the
+// same gob format as GobEncode without building the whole encoding in
+// memory first, and returns the number of bytes written.
+func (o *Map[K, V]) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	err := o.encodeGob(gob.NewEncoder(cw))
+	return cw.n, err
+}
+
+// ReadFrom implements io.ReaderFrom interface. It reads a map written by
+// WriteTo or GobEncode from r into the map and returns the number of bytes
+// read. Unless r implements io.ByteReader, e.g. a bufio.Reader, it may read
+// past the end of the map, so r should not hold anything else.
+func (o *Map[K, V]) ReadFrom(r io.Reader) (int64, error) {
+	cr := newCountingReader(r)
+	err := o.decodeGob(gob.NewDecoder(cr))
+	return cr.count(), err
+}
+
+// ReadFrom reads a map like Map.ReadFrom and records the inserted entries
+// as a single version. The entries read before an error stay recorded.
+func (o *VersionedMap[K, V]) ReadFrom(r io.Reader) (int64, error) {
+	cr := newCountingReader(r)
+	var err error
+	o.group(func() {
+		err = o.decodeGobWith(gob.NewDecoder(cr), o.PutE)
+	})
+	return cr.count(), err
+}
+
+// WriteTo implements io.WriterTo interface. It writes the set to w in the
+// same gob format as GobEncode and returns the number of bytes written.
+func (s *Set[T]) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	err := s.encodeGob(gob.NewEncoder(cw))
+	return cw.n, err
+}
+
+// ReadFrom implements io.ReaderFrom interface. It reads a set written by
+// WriteTo or GobEncode from r into the set and returns the number of bytes
+// read. Like Map.ReadFrom, it may read past the end of the set.
+func (s *Set[T]) ReadFrom(r io.Reader) (int64, error) {
+	cr := newCountingReader(r)
+	err := s.decodeGob(gob.NewDecoder(cr))
+	return cr.count(), err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+func (cr *countingReader) count() int64 {
+	return cr.n
+}
+
+// countingByteReader keeps the io.ByteReader of the underlying reader, so
+// that gob does not buffer it and reads only the bytes it needs.
+type countingByteReader struct {
+	countingReader
+	br io.ByteReader
+}
+
+func (cr *countingByteReader) ReadByte() (byte, error) {
+	b, err := cr.br.ReadByte()
+	if err == nil {
+		cr.n++
+	}
+	return b, err
+}
+
+func newCountingReader(r io.Reader) interface {
+	io.Reader
+	count() int64
+} {
+	if br, ok := r.(io.ByteReader); ok {
+		return &countingByteReader{countingReader: countingReader{r: r}, br: br}
+	}
+	return &countingReader{r: r}
+}
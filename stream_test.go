@@ -0,0 +1,117 @@
+package ordered_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapWriteToReadFrom(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMapWithKVs(kv{"c", 3}, kv{"a", 1}, kv{"b", 2})
+
+	var buf bytes.Buffer
+	n, err := om.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	b, err := om.GobEncode()
+	assert.NoError(t, err)
+	assert.Equal(t, b, buf.Bytes())
+
+	decoded := ordered.NewMap[string, int]()
+	m, err := decoded.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, n, m)
+	assert.Equal(t, om.KeyValues(), decoded.KeyValues())
+
+	t.Run("codec", func(t *testing.T) {
+		om := ordered.NewMap[coord, string]()
+		om.SetKeyCodec(coordCodec)
+		om.Put(coord{1, 2}, "a")
+
+		var buf bytes.Buffer
+		_, err := om.WriteTo(&buf)
+		assert.NoError(t, err)
+
+		var decoded ordered.Map[coord, string]
+		decoded.SetKeyCodec(coordCodec)
+		_, err = decoded.ReadFrom(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, om.KeyValues(), decoded.KeyValues())
+	})
+
+	t.Run("consecutive", func(t *testing.T) {
+		first := ordered.NewMapWithKVs(kv{"x", 1})
+		second := ordered.NewMapWithKVs(kv{"y", 2}, kv{"z", 3})
+		var buf bytes.Buffer
+		first.WriteTo(&buf)
+		second.WriteTo(&buf)
+
+		r := bufio.NewReader(&buf)
+		a, b := ordered.NewMap[string, int](), ordered.NewMap[string, int]()
+		_, err := a.ReadFrom(r)
+		assert.NoError(t, err)
+		_, err = b.ReadFrom(r)
+		assert.NoError(t, err)
+		assert.Equal(t, first.KeyValues(), a.KeyValues())
+		assert.Equal(t, second.KeyValues(), b.KeyValues())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ordered.NewMap[string, int]().ReadFrom(bytes.NewReader(nil))
+		assert.ErrorIs(t, err, io.EOF)
+
+		errWrite := errors.New("write error")
+		_, err = om.WriteTo(failingWriter{errWrite})
+		assert.ErrorIs(t, err, errWrite)
+	})
+}
+
+func TestSetWriteToReadFrom(t *testing.T) {
+	s := ordered.NewSetWithElems(3, 1, 2)
+
+	var buf bytes.Buffer
+	n, err := s.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	var decoded ordered.Set[int]
+	m, err := decoded.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, n, m)
+	assert.Equal(t, []int{3, 1, 2}, decoded.Elements())
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func TestVersionedMapReadFrom(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	var buf bytes.Buffer
+	ordered.NewMapWithKVs(kv{"b", 2}, kv{"c", 3}).WriteTo(&buf)
+
+	vm := ordered.NewVersionedMap[string, int]()
+	vm.Put("a", 1)
+	_, err := vm.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, vm.Keys())
+
+	assert.True(t, vm.Undo())
+	assert.Equal(t, []string{"a"}, vm.Keys())
+	assert.True(t, vm.CanUndo())
+
+	_, err = vm.ReadFrom(bytes.NewReader(nil))
+	assert.Error(t, err)
+	assert.Equal(t, []string{"a"}, vm.Keys())
+}
@@ -0,0 +1,45 @@
+package ordered
+
+import "time"
+
+type entryTimes struct {
+	inserted time.Time
+	updated  time.Time
+}
+
+// InsertedAt returns the time when the given key was inserted in the map and
+// a bool indicating whether the time is known or not. The time is known only
+// if the key exists and the map is created with the WithTimestamps option.
+func (o *Map[K, V]) InsertedAt(key K) (time.Time, bool) {
+	if vp, ok := o.lookup(key); ok && vp.times != nil {
+		return vp.times.inserted, true
+	}
+	return time.Time{}, false
+}
+
+// UpdatedAt returns the time when the mapped value of the given key was last
+// put in the map and a bool indicating whether the time is known or not. The
+// time is known only if the key exists and the map is created with the
+// WithTimestamps option.
+func (o *Map[K, V]) UpdatedAt(key K) (time.Time, bool) {
+	if vp, ok := o.lookup(key); ok && vp.times != nil {
+		return vp.times.updated, true
+	}
+	return time.Time{}, false
+}
+
+// RangeInsertedBetween invokes the given function f for each element of the
+// map inserted between from and to inclusive according to the insertion
+// order. It does nothing if the map is not created with the WithTimestamps
+// option.
+func (o *Map[K, V]) RangeInsertedBetween(from, to time.Time, f func(K, V)) {
+	for _, kv := range o.KeyValues() {
+		vp, ok := o.lookup(kv.Key)
+		if !ok || vp.times == nil {
+			continue
+		}
+		if t := vp.times.inserted; !t.Before(from) && !t.After(to) {
+			f(kv.Key, kv.Value)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package ordered_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock returns a clock which advances by one second on every read.
+func fakeClock(start time.Time) func() time.Time {
+	now := start
+	return func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+func TestInsertedAt(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	om := ordered.NewMap[string, int](ordered.WithTimestamps(), ordered.WithClock(fakeClock(start)))
+
+	om.Put("foo", 1)
+	om.Put("bar", 2)
+	om.Put("foo", 3)
+
+	ts, ok := om.InsertedAt("foo")
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(1*time.Second), ts)
+
+	ts, ok = om.UpdatedAt("foo")
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(3*time.Second), ts)
+
+	ts, ok = om.InsertedAt("bar")
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(2*time.Second), ts)
+
+	_, ok = om.InsertedAt("baz")
+	assert.False(t, ok)
+
+	// re-insertion after removal records a new insertion time
+	om.Remove("bar")
+	om.Put("bar", 4)
+	ts, _ = om.InsertedAt("bar")
+	assert.Equal(t, start.Add(4*time.Second), ts)
+}
+
+func TestInsertedAtWithoutTimestamps(t *testing.T) {
+	om := ordered.NewMap[string, int]()
+	om.Put("foo", 1)
+
+	_, ok := om.InsertedAt("foo")
+	assert.False(t, ok)
+
+	_, ok = om.UpdatedAt("foo")
+	assert.False(t, ok)
+}
+
+func TestRangeInsertedBetween(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	om := ordered.NewMap[string, int](ordered.WithTimestamps(), ordered.WithClock(fakeClock(start)))
+	om.Put("a", 1)
+	om.Put("b", 2)
+	om.Put("c", 3)
+	om.Put("d", 4)
+	om.Put("b", 20)
+
+	var keys []string
+	var vals []int
+	om.RangeInsertedBetween(start.Add(2*time.Second), start.Add(3*time.Second), func(k string, v int) {
+		keys = append(keys, k)
+		vals = append(vals, v)
+	})
+	assert.Equal(t, []string{"b", "c"}, keys)
+	assert.Equal(t, []int{20, 3}, vals)
+
+	keys = nil
+	om.RangeInsertedBetween(start.Add(10*time.Second), start.Add(20*time.Second), func(k string, v int) {
+		keys = append(keys, k)
+	})
+	assert.Empty(t, keys)
+
+	t.Run("real clock", func(t *testing.T) {
+		before := time.Now()
+		om := ordered.NewMap[string, int](ordered.WithTimestamps())
+		om.Put("foo", 1)
+		after := time.Now()
+
+		var keys []string
+		om.RangeInsertedBetween(before, after, func(k string, v int) {
+			keys = append(keys, k)
+		})
+		assert.Equal(t, []string{"foo"}, keys)
+	})
+}
@@ -0,0 +1,319 @@
+package ordered
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+)
+
+// trieNode is a node of the radix tree behind TrieMap. The label is the
+// part of the key on the edge from the parent. A node holds a key if its
+// pair is not nil.
+type trieNode[V any] struct {
+	label    string
+	parent   *trieNode[V]
+	children []*trieNode[V] // sorted by the first byte of the label
+	vp       *valuePair[V]
+	seq      uint64
+}
+
+// key rebuilds the key of the node from the labels up to the root.
+func (n *trieNode[V]) key() string {
+	size := 0
+	for p := n; p != nil; p = p.parent {
+		size += len(p.label)
+	}
+	b := make([]byte, size)
+	for p := n; p != nil; p = p.parent {
+		size -= len(p.label)
+		copy(b[size:], p.label)
+	}
+	return string(b)
+}
+
+// child returns the index of the child whose label starts with c and a bool
+// indicating whether such a child exists or not.
+func (n *trieNode[V]) child(c byte) (int, bool) {
+	idx := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label[0] >= c
+	})
+	return idx, idx < len(n.children) && n.children[idx].label[0] == c
+}
+
+func (n *trieNode[V]) addChild(c *trieNode[V]) {
+	idx, _ := n.child(c.label[0])
+	n.children = append(n.children, nil)
+	copy(n.children[idx+1:], n.children[idx:])
+	n.children[idx] = c
+	c.parent = n
+}
+
+func (n *trieNode[V]) removeChild(c *trieNode[V]) {
+	idx, _ := n.child(c.label[0])
+	copy(n.children[idx:], n.children[idx+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// WithRadixTree stores the keys of the map or set in a radix tree instead
+// of a hashmap. The keys sharing a prefix share the memory of the prefix,
+// which cuts the memory used by lots of keys with long common prefixes
+// e.g. file paths and URL routes. Lookups take time proportional to the
+// length of the key. The insertion order and the methods of the map are
+// not affected, so the option can be added to any map with string keys.
+// The construction panics if the key type is not string. See TrieMap for
+// the prefix scans.
+func WithRadixTree() Option {
+	return func(c *config) {
+		c.radixTree = true
+	}
+}
+
+// trieIndex is the keyIndex set by WithRadixTree. The list elements hold
+// the nodes of the keys, so that the keys are only stored in the labels.
+type trieIndex[V any] struct {
+	root  *trieNode[V]
+	items *list.List
+}
+
+func newTrieIndex[V any](items *list.List) *trieIndex[V] {
+	return &trieIndex[V]{root: &trieNode[V]{}, items: items}
+}
+
+// find returns the node holding the key or nil.
+func (t *trieIndex[V]) find(key string) *trieNode[V] {
+	n := t.root
+	for len(key) > 0 {
+		idx, ok := n.child(key[0])
+		if !ok {
+			return nil
+		}
+		c := n.children[idx]
+		if !strings.HasPrefix(key, c.label) {
+			return nil
+		}
+		key = key[len(c.label):]
+		n = c
+	}
+	if n.vp == nil {
+		return nil
+	}
+	return n
+}
+
+// insert returns the node for the key creating it if needed.
+func (t *trieIndex[V]) insert(key string) *trieNode[V] {
+	n := t.root
+	for len(key) > 0 {
+		idx, ok := n.child(key[0])
+		if !ok {
+			c := &trieNode[V]{label: cloneString(key)}
+			n.addChild(c)
+			return c
+		}
+		c := n.children[idx]
+		common := commonPrefixLen(key, c.label)
+		if common < len(c.label) {
+			// split the edge keeping c as the lower node, so that the list
+			// elements pointing to it stay valid
+			mid := &trieNode[V]{label: cloneString(c.label[:common]), parent: n}
+			n.children[idx] = mid
+			c.label = cloneString(c.label[common:])
+			mid.addChild(c)
+			c = mid
+		}
+		key = key[common:]
+		n = c
+	}
+	return n
+}
+
+// prune removes the node which does not hold a key anymore and merges the
+// nodes which are left with a single child.
+func (t *trieIndex[V]) prune(n *trieNode[V]) {
+	for n != t.root && n.vp == nil {
+		switch len(n.children) {
+		case 0:
+			p := n.parent
+			p.removeChild(n)
+			n.parent = nil
+			n = p
+			continue
+		case 1:
+			c := n.children[0]
+			c.label = n.label + c.label
+			idx, _ := n.parent.child(n.label[0])
+			n.parent.children[idx] = c
+			c.parent = n.parent
+			n.parent, n.children = nil, nil
+		}
+		return
+	}
+}
+
+func (t *trieIndex[V]) get(key string) (*valuePair[V], bool) {
+	if n := t.find(key); n != nil {
+		return n.vp, true
+	}
+	return nil, false
+}
+
+func (t *trieIndex[V]) set(key string, vp *valuePair[V]) {
+	n := t.insert(key)
+	n.vp = vp
+	vp.elem.Value = n
+	t.number(n)
+}
+
+func (t *trieIndex[V]) delete(key string) {
+	if n := t.find(key); n != nil {
+		n.vp = nil
+		t.prune(n)
+	}
+}
+
+func (t *trieIndex[V]) clear() {
+	t.root = &trieNode[V]{}
+}
+
+func (t *trieIndex[V]) entry(e *list.Element) (string, *valuePair[V]) {
+	n := e.Value.(*trieNode[V])
+	return n.key(), n.vp
+}
+
+// seqGap is the gap between the sequence numbers of the keys appended to
+// the list, so that a key re-inserted between two others, e.g. by an undo
+// of VersionedMap, mostly finds a free number.
+const seqGap = 1 << 16
+
+// number sets the sequence number of the node between the ones of its
+// neighbors in the list, so that the prefix scans can sort the nodes by
+// the insertion order. All the nodes are renumbered if there is no free
+// number between the neighbors.
+func (t *trieIndex[V]) number(n *trieNode[V]) {
+	var lo uint64
+	if prev := n.vp.elem.Prev(); prev != nil {
+		lo = prev.Value.(*trieNode[V]).seq
+	}
+	next := n.vp.elem.Next()
+	if next == nil {
+		n.seq = lo + seqGap
+		return
+	}
+	if hi := next.Value.(*trieNode[V]).seq; hi-lo > 1 {
+		n.seq = lo + (hi-lo)/2
+		return
+	}
+	var seq uint64
+	for e := t.items.Front(); e != nil; e = e.Next() {
+		seq += seqGap
+		e.Value.(*trieNode[V]).seq = seq
+	}
+}
+
+// withPrefix returns the nodes of the keys having the given prefix sorted
+// by the insertion order. Only the subtree of the prefix is visited.
+func (t *trieIndex[V]) withPrefix(prefix string) []*trieNode[V] {
+	n := t.root
+	for len(prefix) > 0 {
+		idx, ok := n.child(prefix[0])
+		if !ok {
+			return nil
+		}
+		c := n.children[idx]
+		common := commonPrefixLen(prefix, c.label)
+		if common < len(prefix) && common < len(c.label) {
+			return nil
+		}
+		prefix = prefix[common:]
+		n = c
+	}
+
+	var nodes []*trieNode[V]
+	var walk func(*trieNode[V])
+	walk = func(n *trieNode[V]) {
+		if n.vp != nil {
+			nodes = append(nodes, n)
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(n)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].seq < nodes[j].seq
+	})
+	return nodes
+}
+
+// TrieMap is an ordered map with string keys backed by a radix tree instead
+// of a hashmap, which enables prefix scans. It is a Map[string, V] created
+// WithRadixTree, so it has all the methods and options of Map and preserves
+// the insertion order likewise.
+type TrieMap[V any] struct {
+	*Map[string, V]
+}
+
+// NewTrieMap initializes a radix tree backed ordered map configured by the
+// given options.
+func NewTrieMap[V any](opts ...Option) *TrieMap[V] {
+	opts = append([]Option{WithRadixTree()}, opts...)
+	return &TrieMap[V]{Map: NewMap[string, V](opts...)}
+}
+
+// KeyValuesWithPrefix returns the keys having the given prefix and their
+// mapped values according to their insertion order. Only the subtree of
+// the prefix is visited.
+func (o *TrieMap[V]) KeyValuesWithPrefix(prefix string) []KeyValue[string, V] {
+	t, ok := o.index.(*trieIndex[V])
+	if !ok {
+		// the wrapped map is not created WithRadixTree
+		kvs := []KeyValue[string, V]{}
+		for _, kv := range o.KeyValues() {
+			if strings.HasPrefix(kv.Key, prefix) {
+				kvs = append(kvs, kv)
+			}
+		}
+		return kvs
+	}
+	nodes := t.withPrefix(prefix)
+	kvs := make([]KeyValue[string, V], len(nodes))
+	for i, n := range nodes {
+		kvs[i] = KeyValue[string, V]{Key: n.key(), Value: n.vp.value}
+	}
+	return kvs
+}
+
+// KeysWithPrefix returns the keys having the given prefix according to
+// their insertion order.
+func (o *TrieMap[V]) KeysWithPrefix(prefix string) []string {
+	kvs := o.KeyValuesWithPrefix(prefix)
+	keys := make([]string, len(kvs))
+	for i, kv := range kvs {
+		keys[i] = kv.Key
+	}
+	return keys
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. A zero value TrieMap
+// is initialized by NewTrieMap before decoding.
+func (o *TrieMap[V]) UnmarshalJSON(b []byte) error {
+	if o.Map == nil {
+		o.Map = NewTrieMap[V]().Map
+	}
+	return o.Map.UnmarshalJSON(b)
+}
+
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// cloneString returns a copy of s which does not share the memory of s, so
+// that a label does not keep the whole key alive.
+func cloneString(s string) string {
+	return string(append([]byte(nil), s...))
+}
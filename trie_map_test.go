@@ -0,0 +1,196 @@
+package ordered_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrieMapPutGet(t *testing.T) {
+	om := ordered.NewTrieMap[int]()
+	assert.True(t, om.IsEmpty())
+
+	om.Put("/usr/local/bin", 1)
+	om.Put("/usr/local/lib", 2)
+	om.Put("/usr", 3)
+	om.Put("/var/log", 4)
+	om.Put("", 5)
+	om.Put("/usr/local/bin", 10)
+
+	assert.Equal(t, 5, om.Len())
+	assert.Equal(t, []string{"/usr/local/bin", "/usr/local/lib", "/usr", "/var/log", ""}, om.Keys())
+	assert.Equal(t, []int{10, 2, 3, 4, 5}, om.Values())
+
+	val, ok := om.Get("/usr")
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+
+	_, ok = om.Get("/usr/local")
+	assert.False(t, ok)
+	assert.False(t, om.ContainsKey("/usr/lo"))
+	assert.False(t, om.ContainsKey("/usr/local/bin/go"))
+	assert.True(t, om.ContainsKey(""))
+	assert.Equal(t, -1, om.GetOrDefault("/opt", -1))
+}
+
+func TestTrieMapRemove(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewTrieMap[int]()
+	om.Put("romane", 1)
+	om.Put("romanus", 2)
+	om.Put("romulus", 3)
+	om.Put("rubens", 4)
+	om.Put("ruber", 5)
+	om.Put("rom", 6)
+
+	assert.Equal(t, 2, om.Remove("romanus"))
+	assert.Equal(t, 0, om.Remove("roman"))
+	assert.Equal(t, 6, om.Remove("rom"))
+	assert.Equal(t, []kv{{"romane", 1}, {"romulus", 3}, {"rubens", 4}, {"ruber", 5}}, om.KeyValues())
+
+	om.Put("romanus", 7)
+	assert.Equal(t, []string{"romane", "romulus", "rubens", "ruber", "romanus"}, om.Keys())
+
+	om.Clear()
+	assert.True(t, om.IsEmpty())
+	assert.False(t, om.ContainsKey("romane"))
+	assert.Equal(t, []string{}, om.Keys())
+}
+
+func TestTrieMapAgainstMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tm := ordered.NewTrieMap[int]()
+	om := ordered.NewMap[string, int]()
+
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("/%d/%d/%d", r.Intn(3), r.Intn(5), r.Intn(10))
+		if r.Intn(3) == 0 {
+			assert.Equal(t, om.Remove(key), tm.Remove(key))
+		} else {
+			tm.Put(key, i)
+			om.Put(key, i)
+		}
+	}
+	assert.Equal(t, om.KeyValues(), tm.KeyValues())
+}
+
+func TestTrieMapWithPrefix(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewTrieMap[int]()
+	om.Put("/api/v2/users", 1)
+	om.Put("/api/v1/users", 2)
+	om.Put("/api/v1/orders", 3)
+	om.Put("/health", 4)
+	om.Put("/api/v1", 5)
+
+	assert.Equal(t, []kv{{"/api/v1/users", 2}, {"/api/v1/orders", 3}, {"/api/v1", 5}}, om.KeyValuesWithPrefix("/api/v1"))
+	assert.Equal(t, []string{"/api/v2/users", "/api/v1/users", "/api/v1/orders", "/api/v1"}, om.KeysWithPrefix("/ap"))
+	assert.Equal(t, []string{"/api/v1/users"}, om.KeysWithPrefix("/api/v1/u"))
+	assert.Equal(t, []string{}, om.KeysWithPrefix("/api/v3"))
+	assert.Equal(t, []string{}, om.KeysWithPrefix("/healthz"))
+	assert.Equal(t, om.Keys(), om.KeysWithPrefix(""))
+}
+
+func TestTrieMapForEach(t *testing.T) {
+	om := ordered.NewTrieMap[int]()
+	om.Put("b", 1)
+	om.Put("a", 2)
+
+	var keys []string
+	om.ForEach(func(k string, v int) {
+		keys = append(keys, k)
+	})
+	assert.Equal(t, []string{"b", "a"}, keys)
+	assert.Equal(t, "map{b:1 a:2}", om.String())
+}
+
+func TestTrieMapJSON(t *testing.T) {
+	om := ordered.NewTrieMap[[]int]()
+	om.Put("foo", []int{1})
+	om.Put("foobar", []int{2, 3})
+
+	b, err := json.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"foo":[1],"foobar":[2,3]}`, string(b))
+
+	var dm ordered.TrieMap[[]int]
+	assert.NoError(t, json.Unmarshal(b, &dm))
+	assert.Equal(t, om.KeyValues(), dm.KeyValues())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"a":"b"}`), &dm))
+}
+
+func TestTrieMapOptions(t *testing.T) {
+	om := ordered.NewTrieMap[int](ordered.WithMaxEntries(2, ordered.PolicyReject), ordered.WithStats(),
+		ordered.WithKeyTransform(strings.ToUpper))
+	assert.NoError(t, om.PutE("a/b", 1))
+	assert.NoError(t, om.PutE("a/c", 2))
+	assert.ErrorIs(t, om.PutE("a/d", 3), ordered.ErrFull)
+	assert.True(t, om.ContainsKey("a/b"))
+	assert.Equal(t, ordered.Stats{Len: 2, Puts: 2, Gets: 1, Hits: 1}, om.Stats())
+
+	b, err := json.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"A/B":1,"A/C":2}`, string(b))
+	assert.Equal(t, "map{a/b:1 a/c:2}", om.FormatString(ordered.DefaultFormatOptions()))
+	assert.Equal(t, uint64(2), om.ModCount())
+}
+
+func TestTrieMapGob(t *testing.T) {
+	om := ordered.NewTrieMap[int]()
+	om.Put("/a/b", 1)
+	om.Put("/a", 2)
+
+	var buf bytes.Buffer
+	_, err := om.WriteTo(&buf)
+	assert.NoError(t, err)
+
+	dm := ordered.NewTrieMap[int]()
+	_, err = dm.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, om.KeyValues(), dm.KeyValues())
+	assert.Equal(t, []string{"/a/b", "/a"}, dm.KeysWithPrefix("/a"))
+}
+
+func TestWithRadixTree(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewMap[string, int](ordered.WithRadixTree())
+	om.Put("foo", 1)
+	om.Put("foobar", 2)
+	om.Put("fo", 3)
+	assert.Equal(t, []kv{{"foo", 1}, {"foobar", 2}, {"fo", 3}}, om.KeyValues())
+
+	it := om.IterateFrom("foobar")
+	assert.True(t, it.Valid())
+	assert.Equal(t, "foobar", it.Key())
+	assert.Equal(t, 2, it.Value())
+
+	s := ordered.NewSet[string](ordered.WithRadixTree())
+	s.Add("x/y")
+	s.Add("x")
+	assert.True(t, s.Remove("x/y"))
+	assert.Equal(t, []string{"x"}, s.Elements())
+
+	assert.PanicsWithValue(t, "ordered: WithRadixTree requires string keys", func() {
+		ordered.NewMap[int, int](ordered.WithRadixTree())
+	})
+}
+
+func TestTrieMapVersionedUndo(t *testing.T) {
+	vm := ordered.NewVersionedMap[string, int](ordered.WithRadixTree())
+	tm := &ordered.TrieMap[int]{Map: vm.Map}
+	vm.Put("/a", 1)
+	vm.Put("/b", 2)
+	vm.Put("/a/x", 3)
+	vm.Remove("/a")
+
+	assert.True(t, vm.Undo())
+	assert.Equal(t, []string{"/a", "/b", "/a/x"}, vm.Keys())
+	assert.Equal(t, []string{"/a", "/a/x"}, tm.KeysWithPrefix("/a"))
+}
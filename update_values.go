@@ -0,0 +1,65 @@
+package ordered
+
+import (
+	"runtime"
+	"sync"
+)
+
+// UpdateValues replaces the mapped value of every key by the value returned
+// by f for the key and its current value in a single pass according to the
+// insertion order. The keys and their order are not changed. The map must
+// not be modified by f.
+func (o *Map[K, V]) UpdateValues(f func(K, V) V) {
+	o.guard.enter()
+	defer o.guard.exit()
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		key, vp := o.entry(e)
+		o.stats.put(false)
+		o.setValue(vp, f(key, vp.value))
+	}
+}
+
+// UpdateValuesParallel replaces the mapped values like UpdateValues, but
+// calls f from the given number of goroutines concurrently, so f must be
+// safe for concurrent use. It is meant for an expensive f on a large map.
+// If workers is not positive, runtime.GOMAXPROCS(0) goroutines are used.
+func (o *Map[K, V]) UpdateValuesParallel(f func(K, V) V, workers int) {
+	o.guard.enter()
+	defer o.guard.exit()
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	n := o.items.Len()
+	keys := make([]K, 0, n)
+	vps := make([]*valuePair[V], 0, n)
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		key, vp := o.entry(e)
+		keys = append(keys, key)
+		vps = append(vps, vp)
+	}
+
+	// only f runs concurrently, the values are set afterwards, so that the
+	// bookkeeping of the map is not shared by the goroutines
+	values := make([]V, n)
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := start + chunk
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				values[i] = f(keys[i], vps[i].value)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	for i, vp := range vps {
+		o.stats.put(false)
+		o.setValue(vp, values[i])
+	}
+}
@@ -0,0 +1,75 @@
+package ordered_test
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateValues(t *testing.T) {
+	type kv = ordered.KeyValue[string, string]
+	om := ordered.NewMapWithKVs(kv{"user", "bob"}, kv{"password", "secret"}, kv{"role", "admin"})
+
+	om.UpdateValues(func(k, v string) string {
+		if k == "password" {
+			return "***"
+		}
+		return strings.ToUpper(v)
+	})
+	assert.Equal(t, []kv{{"user", "BOB"}, {"password", "***"}, {"role", "ADMIN"}}, om.KeyValues())
+
+	t.Run("stats and mod count", func(t *testing.T) {
+		om := ordered.NewMap[int, int](ordered.WithStats())
+		om.Put(1, 1)
+		om.Put(2, 2)
+		before := om.ModCount()
+
+		om.UpdateValues(func(_, v int) int { return v + 1 })
+		assert.Equal(t, uint64(4), om.Stats().Puts)
+		assert.Equal(t, before+2, om.ModCount())
+	})
+
+	t.Run("versioned", func(t *testing.T) {
+		vm := ordered.NewVersionedMap[string, int]()
+		vm.Put("a", 1)
+		vm.Put("b", 2)
+		vm.UpdateValues(func(_ string, v int) int { return v * 10 })
+		assert.Equal(t, []int{10, 20}, vm.Values())
+
+		assert.True(t, vm.Undo())
+		assert.Equal(t, []int{1, 2}, vm.Values())
+		assert.True(t, vm.Redo())
+		assert.Equal(t, []int{10, 20}, vm.Values())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		om := ordered.NewMap[int, int]()
+		om.UpdateValues(func(_, v int) int { return v })
+		om.UpdateValuesParallel(func(_, v int) int { return v }, 4)
+		assert.True(t, om.IsEmpty())
+	})
+}
+
+func TestUpdateValuesParallel(t *testing.T) {
+	om := ordered.NewMap[int, int]()
+	for i := 0; i < 1000; i++ {
+		om.Put(i, i)
+	}
+
+	var calls int64
+	om.UpdateValuesParallel(func(k, v int) int {
+		atomic.AddInt64(&calls, 1)
+		return k + v
+	}, 7)
+	assert.Equal(t, int64(1000), calls)
+	for i, kv := range om.KeyValues() {
+		assert.Equal(t, i, kv.Key)
+		assert.Equal(t, 2*i, kv.Value)
+	}
+
+	om.UpdateValuesParallel(func(_, v int) int { return -v }, 0)
+	assert.Equal(t, -10, om.GetOrDefault(5, 0))
+}
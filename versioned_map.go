@@ -0,0 +1,274 @@
+package ordered
+
+// change records a single modification of a key in a versioned map.
+type change[K comparable, V any] struct {
+	key     K
+	value   V // mapped value after the change
+	old     V // mapped value before the change
+	existed bool
+	exists  bool
+	times   *entryTimes
+	// next is the key following a removed key, used to restore its position
+	next    K
+	hasNext bool
+}
+
+// VersionedMap is an ordered map which records its modifications in a
+// history to support undo and redo. Every Put, Remove and Clear creates a
+// new version unless the modifications are grouped by BeginVersion and
+// Commit. Insert, UpdateValues, ReadFrom and an applied Batch record their
+// changes as a single version.
+// All the methods of the wrapped map are available on it, but modifying the
+// wrapped map directly, e.g. by decoding into it, bypasses the history.
+type VersionedMap[K comparable, V any] struct {
+	*Map[K, V]
+	undo      [][]change[K, V]
+	redo      [][]change[K, V]
+	pending   []change[K, V]
+	inVersion bool
+}
+
+// NewVersionedMap initializes a versioned ordered map configured by the
+// given options.
+func NewVersionedMap[K comparable, V any](opts ...Option) *VersionedMap[K, V] {
+	return &VersionedMap[K, V]{Map: NewMap[K, V](opts...)}
+}
+
+// Put inserts a key and its mapped value in the map and records the change.
+func (o *VersionedMap[K, V]) Put(key K, value V) {
+	o.PutE(key, value)
+}
+
+// PutE inserts a key and its mapped value in the map and records the change
+// like Put. It returns ErrFull if the key is new and the map already holds
+// the maximum number of entries set by WithMaxEntries.
+func (o *VersionedMap[K, V]) PutE(key K, value V) error {
+	c := change[K, V]{key: key, value: value, exists: true}
+	if vp, ok := o.lookup(key); ok {
+		c.old, c.existed = vp.value, true
+	}
+	if err := o.Map.PutE(key, value); err != nil {
+		return err
+	}
+	o.record(c)
+	return nil
+}
+
+// Remove removes the key with its mapped value from the map, records the
+// change and returns the value if the key exists.
+func (o *VersionedMap[K, V]) Remove(key K) V {
+	vp, ok := o.lookup(key)
+	if !ok {
+		var dummy V
+		return dummy
+	}
+	c := o.removal(key, vp)
+	o.Map.Remove(key)
+	o.record(c)
+	return c.old
+}
+
+// Clear removes all the keys and their mapped values from the map and
+// records the changes as a single version.
+func (o *VersionedMap[K, V]) Clear() {
+	if o.IsEmpty() {
+		return
+	}
+	changes := make([]change[K, V], 0, o.Len())
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		key, vp := o.entry(e)
+		changes = append(changes, o.removal(key, vp))
+	}
+	o.Map.Clear()
+	if o.inVersion {
+		o.pending = append(o.pending, changes...)
+	} else {
+		o.push(changes)
+	}
+}
+
+// UpdateValues replaces the mapped values like Map.UpdateValues and records
+// the changes as a single version.
+func (o *VersionedMap[K, V]) UpdateValues(f func(K, V) V) {
+	o.recordUpdates(func() { o.Map.UpdateValues(f) })
+}
+
+// UpdateValuesParallel replaces the mapped values like
+// Map.UpdateValuesParallel and records the changes as a single version.
+func (o *VersionedMap[K, V]) UpdateValuesParallel(f func(K, V) V, workers int) {
+	o.recordUpdates(func() { o.Map.UpdateValuesParallel(f, workers) })
+}
+
+// recordUpdates records the changes of the values made by update.
+func (o *VersionedMap[K, V]) recordUpdates(update func()) {
+	if o.IsEmpty() {
+		return
+	}
+	changes := make([]change[K, V], 0, o.Len())
+	for e := o.items.Front(); e != nil; e = e.Next() {
+		key, vp := o.entry(e)
+		changes = append(changes, change[K, V]{key: key, old: vp.value, existed: true, exists: true})
+	}
+	update()
+	for i := range changes {
+		vp, _ := o.lookup(changes[i].key)
+		changes[i].value = vp.value
+	}
+	if o.inVersion {
+		o.pending = append(o.pending, changes...)
+	} else {
+		o.push(changes)
+	}
+}
+
+// group records the changes made by f as a single version, or as a part of
+// the begun version.
+func (o *VersionedMap[K, V]) group(f func()) {
+	if o.inVersion {
+		f()
+		return
+	}
+	o.inVersion = true
+	defer o.Commit()
+	f()
+}
+
+// BeginVersion starts grouping the following modifications into a single
+// version until Commit or Rollback is called. It panics if a version is
+// already begun.
+func (o *VersionedMap[K, V]) BeginVersion() {
+	if o.inVersion {
+		panic("ordered: version already begun")
+	}
+	o.inVersion = true
+}
+
+// Commit ends the version started by BeginVersion and adds it to the
+// history. It panics if no version is begun.
+func (o *VersionedMap[K, V]) Commit() {
+	if !o.inVersion {
+		panic("ordered: no version begun")
+	}
+	o.inVersion = false
+	if len(o.pending) > 0 {
+		o.push(o.pending)
+	}
+	o.pending = nil
+}
+
+// Rollback reverts the modifications made after BeginVersion and ends the
+// version without adding it to the history. It panics if no version is
+// begun.
+func (o *VersionedMap[K, V]) Rollback() {
+	if !o.inVersion {
+		panic("ordered: no version begun")
+	}
+	o.inVersion = false
+	o.revert(o.pending)
+	o.pending = nil
+}
+
+// Undo reverts the latest version and returns true. It returns false if
+// there is no version to undo. It panics if a version is begun.
+func (o *VersionedMap[K, V]) Undo() bool {
+	if o.inVersion {
+		panic("ordered: undo inside a version")
+	}
+	if len(o.undo) == 0 {
+		return false
+	}
+	changes := o.undo[len(o.undo)-1]
+	o.undo = o.undo[:len(o.undo)-1]
+	o.revert(changes)
+	o.redo = append(o.redo, changes)
+	return true
+}
+
+// Redo re-applies the latest undone version and returns true. It returns
+// false if there is no version to redo. It panics if a version is begun.
+func (o *VersionedMap[K, V]) Redo() bool {
+	if o.inVersion {
+		panic("ordered: redo inside a version")
+	}
+	if len(o.redo) == 0 {
+		return false
+	}
+	changes := o.redo[len(o.redo)-1]
+	o.redo = o.redo[:len(o.redo)-1]
+	for _, c := range changes {
+		if c.exists {
+			o.Map.Put(c.key, c.value)
+		} else {
+			o.Map.Remove(c.key)
+		}
+	}
+	o.undo = append(o.undo, changes)
+	return true
+}
+
+// CanUndo checks if there is any version to undo.
+func (o *VersionedMap[K, V]) CanUndo() bool {
+	return len(o.undo) > 0
+}
+
+// CanRedo checks if there is any undone version to redo.
+func (o *VersionedMap[K, V]) CanRedo() bool {
+	return len(o.redo) > 0
+}
+
+// ClearHistory discards all the recorded versions. The content of the map
+// is not changed.
+func (o *VersionedMap[K, V]) ClearHistory() {
+	o.undo = nil
+	o.redo = nil
+}
+
+func (o *VersionedMap[K, V]) removal(key K, vp *valuePair[V]) change[K, V] {
+	c := change[K, V]{key: key, old: vp.value, existed: true, times: vp.times}
+	if next := vp.elem.Next(); next != nil {
+		c.next, c.hasNext = o.keyOf(next), true
+	}
+	return c
+}
+
+func (o *VersionedMap[K, V]) record(c change[K, V]) {
+	if o.inVersion {
+		o.pending = append(o.pending, c)
+	} else {
+		o.push([]change[K, V]{c})
+	}
+}
+
+func (o *VersionedMap[K, V]) push(changes []change[K, V]) {
+	o.undo = append(o.undo, changes)
+	o.redo = nil
+}
+
+// revert reverts the given changes in the reverse order.
+func (o *VersionedMap[K, V]) revert(changes []change[K, V]) {
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		switch {
+		case !c.existed:
+			o.Map.Remove(c.key)
+		case c.exists:
+			o.Map.Put(c.key, c.old)
+		default:
+			o.restore(c)
+		}
+	}
+}
+
+// restore re-inserts a removed key at its original position.
+func (o *VersionedMap[K, V]) restore(c change[K, V]) {
+	vp := &valuePair[V]{value: c.old, times: c.times}
+	if next, ok := o.lookup(c.next); c.hasNext && ok {
+		vp.elem = o.items.InsertBefore(c.key, next.elem)
+	} else {
+		vp.elem = o.items.PushBack(c.key)
+	}
+	o.store(c.key, vp)
+	o.stats.restore()
+	o.guard.modified()
+	o.mods++
+}
@@ -0,0 +1,148 @@
+package ordered_test
+
+import (
+	"testing"
+
+	"github.com/nhAnik/ordered"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionedMapUndoRedo(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewVersionedMap[string, int]()
+	assert.False(t, om.Undo())
+	assert.False(t, om.Redo())
+
+	om.Put("a", 1)
+	om.Put("b", 2)
+	om.Put("c", 3)
+	om.Put("a", 10)
+	om.Remove("b")
+	om.Remove("z")
+	assert.Equal(t, []kv{{"a", 10}, {"c", 3}}, om.KeyValues())
+
+	// undo removal restores the original position
+	assert.True(t, om.Undo())
+	assert.Equal(t, []kv{{"a", 10}, {"b", 2}, {"c", 3}}, om.KeyValues())
+
+	// undo update restores the old value
+	assert.True(t, om.Undo())
+	assert.Equal(t, []kv{{"a", 1}, {"b", 2}, {"c", 3}}, om.KeyValues())
+
+	// undo insertion removes the key
+	assert.True(t, om.Undo())
+	assert.Equal(t, []kv{{"a", 1}, {"b", 2}}, om.KeyValues())
+	assert.True(t, om.CanRedo())
+
+	assert.True(t, om.Redo())
+	assert.True(t, om.Redo())
+	assert.True(t, om.Redo())
+	assert.False(t, om.Redo())
+	assert.Equal(t, []kv{{"a", 10}, {"c", 3}}, om.KeyValues())
+
+	// a new modification discards the undone versions
+	om.Undo()
+	om.Put("d", 4)
+	assert.False(t, om.CanRedo())
+	assert.Equal(t, []kv{{"a", 10}, {"b", 2}, {"c", 3}, {"d", 4}}, om.KeyValues())
+}
+
+func TestVersionedMapClear(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewVersionedMap[string, int]()
+	om.Put("a", 1)
+	om.Put("b", 2)
+	om.Put("c", 3)
+
+	om.Clear()
+	assert.True(t, om.IsEmpty())
+
+	assert.True(t, om.Undo())
+	assert.Equal(t, []kv{{"a", 1}, {"b", 2}, {"c", 3}}, om.KeyValues())
+
+	assert.True(t, om.Redo())
+	assert.True(t, om.IsEmpty())
+}
+
+func TestVersionedMapCommit(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewVersionedMap[string, int]()
+	om.Put("a", 1)
+
+	om.BeginVersion()
+	om.Put("b", 2)
+	om.Remove("a")
+	om.Put("c", 3)
+	om.Commit()
+	assert.Equal(t, []kv{{"b", 2}, {"c", 3}}, om.KeyValues())
+
+	assert.True(t, om.Undo())
+	assert.Equal(t, []kv{{"a", 1}}, om.KeyValues())
+
+	assert.True(t, om.Redo())
+	assert.Equal(t, []kv{{"b", 2}, {"c", 3}}, om.KeyValues())
+
+	// an empty version is not recorded
+	om.BeginVersion()
+	om.Commit()
+	assert.True(t, om.Undo())
+	assert.Equal(t, []kv{{"a", 1}}, om.KeyValues())
+}
+
+func TestVersionedMapRollback(t *testing.T) {
+	type kv = ordered.KeyValue[string, int]
+	om := ordered.NewVersionedMap[string, int]()
+	om.Put("a", 1)
+	om.Put("b", 2)
+
+	om.BeginVersion()
+	om.Put("a", 10)
+	om.Remove("a")
+	om.Put("c", 3)
+	om.Clear()
+	om.Put("d", 4)
+	om.Rollback()
+
+	assert.Equal(t, []kv{{"a", 1}, {"b", 2}}, om.KeyValues())
+	assert.False(t, om.CanRedo())
+
+	assert.True(t, om.Undo())
+	assert.Equal(t, []kv{{"a", 1}}, om.KeyValues())
+}
+
+func TestVersionedMapMisuse(t *testing.T) {
+	om := ordered.NewVersionedMap[string, int]()
+
+	assert.Panics(t, func() { om.Commit() })
+	assert.Panics(t, func() { om.Rollback() })
+
+	om.BeginVersion()
+	assert.Panics(t, func() { om.BeginVersion() })
+	assert.Panics(t, func() { om.Undo() })
+	assert.Panics(t, func() { om.Redo() })
+}
+
+func TestVersionedMapClearHistory(t *testing.T) {
+	om := ordered.NewVersionedMap[string, int]()
+	om.Put("a", 1)
+	om.Put("b", 2)
+	om.Undo()
+
+	om.ClearHistory()
+	assert.False(t, om.CanUndo())
+	assert.False(t, om.CanRedo())
+	assert.Equal(t, []string{"a"}, om.Keys())
+}
+
+func TestVersionedMapMaxEntries(t *testing.T) {
+	om := ordered.NewVersionedMap[string, int](ordered.WithMaxEntries(1, ordered.PolicyReject))
+	om.Put("a", 1)
+
+	assert.ErrorIs(t, om.PutE("b", 2), ordered.ErrFull)
+	om.Put("c", 3)
+
+	// rejected insertions are not recorded
+	assert.True(t, om.Undo())
+	assert.False(t, om.CanUndo())
+	assert.True(t, om.IsEmpty())
+}